@@ -0,0 +1,9 @@
+# Look up the organization the admin key belongs to
+data "anthropic_organization" "current" {}
+
+output "organization" {
+  value = {
+    id   = data.anthropic_organization.current.id
+    name = data.anthropic_organization.current.name
+  }
+}
@@ -0,0 +1,18 @@
+# Manage all service keys of a workspace in one resource
+resource "anthropic_api_key_set" "services" {
+  workspace_id = anthropic_workspace.production.id
+
+  keys = {
+    ingest  = {}
+    billing = { name = "billing-service" }
+    legacy  = { status = "inactive" }
+  }
+}
+
+# Output the key IDs and hints for each service
+output "service_key_hints" {
+  value = {
+    for name, key in anthropic_api_key_set.services.api_keys :
+    name => { id = key.id, hint = key.hint }
+  }
+}
@@ -0,0 +1,244 @@
+// Package importgen generates Terraform 1.5+ import blocks and skeleton
+// resource configuration for an existing Anthropic organization.
+package importgen
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+)
+
+// Run executes the generate-imports command with the given arguments,
+// writing the generated configuration to stdout unless -output is set.
+func Run(ctx context.Context, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("generate-imports", flag.ContinueOnError)
+	output := flags.String("output", "", "file to write the generated configuration to (defaults to stdout)")
+	baseURL := flags.String("base-url", os.Getenv("ANTHROPIC_BASE_URL"), "base URL for the Anthropic API")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// The key is only read from the environment to keep it out of process listings
+	adminKey := os.Getenv("ANTHROPIC_ADMIN_KEY")
+	if adminKey == "" {
+		return fmt.Errorf("the ANTHROPIC_ADMIN_KEY environment variable must be set")
+	}
+
+	c := adminapi.NewClient(adminKey, adminapi.WithBaseURL(*baseURL))
+
+	var buf bytes.Buffer
+	if err := Generate(ctx, c, &buf); err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err := stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(*output, buf.Bytes(), 0o644)
+}
+
+// Generate enumerates the organization's workspaces, API keys, workspace
+// members and invites and writes an import block plus a skeleton resource
+// for each of them to w.
+func Generate(ctx context.Context, c adminapi.AdminAPI, w io.Writer) error {
+	g := &generator{
+		w:      w,
+		labels: map[string]map[string]bool{},
+	}
+
+	workspaces, err := c.ListAllWorkspaces(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list workspaces: %w", err)
+	}
+
+	apiKeys, err := c.ListAllAPIKeys(ctx, "", "")
+	if err != nil {
+		return fmt.Errorf("unable to list API keys: %w", err)
+	}
+
+	invites, err := c.ListAllInvites(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list invites: %w", err)
+	}
+
+	g.printf("# Generated by terraform-provider-anthropic generate-imports.\n")
+	g.printf("# Review the skeleton resources before running terraform plan.\n")
+
+	// Workspace references let keys and members follow renames in configuration
+	workspaceRefs := map[string]string{}
+	for _, workspace := range workspaces {
+		if workspace.ArchivedAt != "" {
+			continue
+		}
+
+		label := g.label("anthropic_workspace", workspace.Name)
+		workspaceRefs[workspace.ID] = fmt.Sprintf("anthropic_workspace.%s.id", label)
+
+		g.resource("anthropic_workspace", label, workspace.ID, [][2]string{
+			{"name", hclString(workspace.Name)},
+		})
+	}
+
+	for _, apiKey := range apiKeys {
+		if apiKey.Status == "archived" {
+			continue
+		}
+
+		attrs := [][2]string{
+			{"name", hclString(apiKey.Name)},
+		}
+		if apiKey.WorkspaceID != "" {
+			attrs = append(attrs, [2]string{"workspace_id", workspaceRef(workspaceRefs, apiKey.WorkspaceID)})
+		}
+		if apiKey.Status != "active" {
+			attrs = append(attrs, [2]string{"status", hclString(apiKey.Status)})
+		}
+
+		g.resource("anthropic_api_key", g.label("anthropic_api_key", apiKey.Name), apiKey.ID, attrs)
+	}
+
+	for _, workspace := range workspaces {
+		if workspace.ArchivedAt != "" {
+			continue
+		}
+
+		members, err := c.ListAllWorkspaceMembers(ctx, workspace.ID)
+		if err != nil {
+			return fmt.Errorf("unable to list members of workspace %s: %w", workspace.ID, err)
+		}
+
+		for _, member := range members {
+			label := g.label("anthropic_workspace_member", workspace.Name+"_"+member.UserID)
+			g.resource("anthropic_workspace_member", label, workspace.ID+"/"+member.UserID, [][2]string{
+				{"workspace_id", workspaceRef(workspaceRefs, workspace.ID)},
+				{"user_id", hclString(member.UserID)},
+				{"workspace_role", hclString(member.WorkspaceRole)},
+			})
+		}
+	}
+
+	for _, invite := range invites {
+		if invite.Status != "pending" {
+			continue
+		}
+
+		g.resource("anthropic_invite", g.label("anthropic_invite", invite.Email), invite.ID, [][2]string{
+			{"email", hclString(invite.Email)},
+			{"role", hclString(invite.Role)},
+		})
+	}
+
+	return g.err
+}
+
+// generator writes HCL blocks, remembering the first write error and the
+// resource labels already in use per resource type.
+type generator struct {
+	w      io.Writer
+	err    error
+	labels map[string]map[string]bool
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	if g.err != nil {
+		return
+	}
+	_, g.err = fmt.Fprintf(g.w, format, args...)
+}
+
+// resource writes an import block and the matching skeleton resource block.
+func (g *generator) resource(resourceType, label, id string, attrs [][2]string) {
+	g.printf("\nimport {\n  to = %s.%s\n  id = %s\n}\n", resourceType, label, hclString(id))
+
+	width := 0
+	for _, attr := range attrs {
+		if len(attr[0]) > width {
+			width = len(attr[0])
+		}
+	}
+
+	g.printf("\nresource %s %s {\n", hclString(resourceType), hclString(label))
+	for _, attr := range attrs {
+		g.printf("  %-*s = %s\n", width, attr[0], attr[1])
+	}
+	g.printf("}\n")
+}
+
+var invalidLabelChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// label derives a unique, valid resource label from name.
+func (g *generator) label(resourceType, name string) string {
+	base := strings.Trim(invalidLabelChars.ReplaceAllString(strings.ToLower(name), "_"), "_-")
+	if base == "" {
+		base = "unnamed"
+	}
+	if base[0] >= '0' && base[0] <= '9' {
+		base = "_" + base
+	}
+
+	used := g.labels[resourceType]
+	if used == nil {
+		used = map[string]bool{}
+		g.labels[resourceType] = used
+	}
+
+	label := base
+	for i := 2; used[label]; i++ {
+		label = fmt.Sprintf("%s_%d", base, i)
+	}
+	used[label] = true
+
+	return label
+}
+
+// workspaceRef returns a reference to the generated workspace resource, or
+// the literal ID if the workspace is not part of the generated configuration.
+func workspaceRef(refs map[string]string, workspaceID string) string {
+	if ref, ok := refs[workspaceID]; ok {
+		return ref
+	}
+	return hclString(workspaceID)
+}
+
+// hclString quotes s as an HCL string literal. HCL only supports the \n, \r,
+// \t, \", \\, \uNNNN and \UNNNNNNNN escapes, so other control and
+// non-printable characters are written as Unicode escapes, invalid UTF-8 is
+// replaced, and template sequences are escaped.
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case r > 0xffff && !unicode.IsPrint(r):
+			fmt.Fprintf(&b, `\U%08X`, r)
+		case !unicode.IsPrint(r) && r != ' ':
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
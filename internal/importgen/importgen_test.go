@@ -0,0 +1,100 @@
+package importgen
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi/fake"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestHCLString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "production", want: `"production"`},
+		{in: "", want: `""`},
+		{in: `say "hi"`, want: `"say \"hi\""`},
+		{in: `C:\keys`, want: `"C:\\keys"`},
+		{in: "line\nbreak\r\tend", want: `"line\nbreak\r\tend"`},
+		{in: "cost-${env}", want: `"cost-$${env}"`},
+		{in: "%{if true}x%{endif}", want: `"%%{if true}x%%{endif}"`},
+		{in: "$5 and 100%", want: `"$5 and 100%"`},
+		{in: "bell\a", want: `"bell\u0007"`},
+		{in: "del\x7f", want: `"del\u007F"`},
+		{in: "café ☕", want: `"café ☕"`},
+		{in: "sep\u2028", want: `"sep\u2028"`},
+		{in: "tag\U000E0001", want: `"tag\U000E0001"`},
+		{in: "bad\xffbyte", want: "\"bad\uFFFDbyte\""},
+	}
+
+	for _, tt := range tests {
+		if got := hclString(tt.in); got != tt.want {
+			t.Errorf("hclString(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	ctx := context.Background()
+	f := fake.NewClient()
+
+	production, err := f.CreateWorkspace(ctx, &adminapi.CreateWorkspaceRequest{Name: "Production"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	archived, err := f.CreateWorkspace(ctx, &adminapi.CreateWorkspaceRequest{Name: "old"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.ArchiveWorkspace(ctx, archived.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.CreateAPIKey(ctx, &adminapi.CreateAPIKeyRequest{Name: "ci ${env}", WorkspaceID: production.ID}); err != nil {
+		t.Fatal(err)
+	}
+	inactive, err := f.CreateAPIKey(ctx, &adminapi.CreateAPIKeyRequest{Name: "ci \"legacy\"\a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.UpdateAPIKey(ctx, inactive.ID, &adminapi.UpdateAPIKeyRequest{Status: "inactive"}); err != nil {
+		t.Fatal(err)
+	}
+
+	f.AddOrganizationMember(adminapi.OrganizationMember{ID: "user_ada", Email: "ada@example.com", Role: "user"})
+	if _, err := f.AddWorkspaceMember(ctx, production.ID, &adminapi.AddWorkspaceMemberRequest{UserID: "user_ada", WorkspaceRole: "workspace_developer"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.CreateInvite(ctx, &adminapi.CreateInviteRequest{Email: "bob@example.com", Role: "developer"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := Generate(ctx, f, &buf); err != nil {
+		t.Fatalf("Generate: %s", err)
+	}
+
+	golden := filepath.Join("testdata", "generate.tf")
+	if *update {
+		if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != string(want) {
+		t.Errorf("Generate output does not match %s, run go test ./internal/importgen -update if the change is intended\ngot:\n%s\nwant:\n%s", golden, got, want)
+	}
+}
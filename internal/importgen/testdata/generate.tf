@@ -0,0 +1,52 @@
+# Generated by terraform-provider-anthropic generate-imports.
+# Review the skeleton resources before running terraform plan.
+
+import {
+  to = anthropic_workspace.production
+  id = "wrkspc_fake0001"
+}
+
+resource "anthropic_workspace" "production" {
+  name = "Production"
+}
+
+import {
+  to = anthropic_api_key.ci_env
+  id = "apikey_fake0003"
+}
+
+resource "anthropic_api_key" "ci_env" {
+  name         = "ci $${env}"
+  workspace_id = anthropic_workspace.production.id
+}
+
+import {
+  to = anthropic_api_key.ci_legacy
+  id = "apikey_fake0004"
+}
+
+resource "anthropic_api_key" "ci_legacy" {
+  name   = "ci \"legacy\"\u0007"
+  status = "inactive"
+}
+
+import {
+  to = anthropic_workspace_member.production_user_ada
+  id = "wrkspc_fake0001/user_ada"
+}
+
+resource "anthropic_workspace_member" "production_user_ada" {
+  workspace_id   = anthropic_workspace.production.id
+  user_id        = "user_ada"
+  workspace_role = "workspace_developer"
+}
+
+import {
+  to = anthropic_invite.bob_example_com
+  id = "invite_fake0005"
+}
+
+resource "anthropic_invite" "bob_example_com" {
+  email = "bob@example.com"
+  role  = "developer"
+}
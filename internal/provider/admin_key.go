@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// defaultAdminKeyCommandTimeout bounds how long admin_key_command may run.
+const defaultAdminKeyCommandTimeout = 30 * time.Second
+
+// adminKeyCommandWaitDelay bounds how long admin_key_command's output is read
+// after the command was killed on timeout.
+const adminKeyCommandWaitDelay = time.Second
+
+// adminKeyCommandCache holds the output of each admin_key_command for the
+// lifetime of the provider process, so that the helper runs once even when
+// Terraform configures the provider several times.
+var adminKeyCommandCache = struct {
+	sync.Mutex
+	keys map[string]string
+}{keys: map[string]string{}}
+
+// resolveAdminKey returns the provider's admin key from admin_key,
+// admin_key_file or admin_key_command, falling back to the
+// ANTHROPIC_ADMIN_KEY and ANTHROPIC_ADMIN_KEY_FILE environment variables.
+func resolveAdminKey(ctx context.Context, config AnthropicProviderModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case !config.AdminKey.IsNull():
+		return config.AdminKey.ValueString(), diags
+
+	case !config.AdminKeyFile.IsNull():
+		key, err := readAdminKeyFile(config.AdminKeyFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("admin_key_file"), "Unable to Read Admin Key", err.Error())
+		}
+		return key, diags
+
+	case !config.AdminKeyCommand.IsNull():
+		var command []string
+		diags.Append(config.AdminKeyCommand.ElementsAs(ctx, &command, false)...)
+		if diags.HasError() {
+			return "", diags
+		}
+
+		timeout := defaultAdminKeyCommandTimeout
+		if !config.AdminKeyCommandTimeout.IsNull() {
+			var err error
+			timeout, err = time.ParseDuration(config.AdminKeyCommandTimeout.ValueString())
+			if err != nil || timeout <= 0 {
+				diags.AddAttributeError(
+					path.Root("admin_key_command_timeout"),
+					"Invalid Timeout",
+					fmt.Sprintf("Expected a positive duration such as 30s or 2m, got: %s", config.AdminKeyCommandTimeout.ValueString()),
+				)
+				return "", diags
+			}
+		}
+
+		key, err := runAdminKeyCommand(ctx, command, timeout)
+		if err != nil {
+			diags.AddAttributeError(path.Root("admin_key_command"), "Unable to Run Admin Key Command", err.Error())
+		}
+		return key, diags
+	}
+
+	if key := os.Getenv("ANTHROPIC_ADMIN_KEY"); key != "" {
+		return key, diags
+	}
+
+	if file := os.Getenv("ANTHROPIC_ADMIN_KEY_FILE"); file != "" {
+		key, err := readAdminKeyFile(file)
+		if err != nil {
+			diags.AddError("Unable to Read Admin Key", fmt.Sprintf("Unable to read ANTHROPIC_ADMIN_KEY_FILE: %s", err))
+		}
+		return key, diags
+	}
+
+	return "", diags
+}
+
+// readAdminKeyFile returns the admin key stored in the file at path.
+func readAdminKeyFile(path string) (string, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read admin key file: %w", err)
+	}
+
+	key := strings.TrimSpace(string(contents))
+	if key == "" {
+		return "", fmt.Errorf("admin key file %s is empty", path)
+	}
+	return key, nil
+}
+
+// runAdminKeyCommand runs command and returns the admin key it prints on
+// stdout. Results are cached per command.
+func runAdminKeyCommand(ctx context.Context, command []string, timeout time.Duration) (string, error) {
+	if len(command) == 0 || command[0] == "" {
+		return "", errors.New("command must not be empty")
+	}
+
+	cacheKey := strings.Join(command, "\x00")
+
+	adminKeyCommandCache.Lock()
+	defer adminKeyCommandCache.Unlock()
+
+	if key, ok := adminKeyCommandCache.keys[cacheKey]; ok {
+		return key, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Don't wait for children of the command that keep its output open
+	cmd.WaitDelay = adminKeyCommandWaitDelay
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("command %q timed out after %s", command[0], timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("command %q failed: %w: %s", command[0], err, msg)
+		}
+		return "", fmt.Errorf("command %q failed: %w", command[0], err)
+	}
+
+	key := strings.TrimSpace(stdout.String())
+	if key == "" {
+		return "", fmt.Errorf("command %q printed no admin key", command[0])
+	}
+
+	adminKeyCommandCache.keys[cacheKey] = key
+	return key, nil
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// resolveAdminKeyError returns the detail of the error resolving config, or
+// the empty string if the admin key resolved.
+func resolveAdminKeyError(t *testing.T, config AnthropicProviderModel) (string, string) {
+	t.Helper()
+
+	key, diags := resolveAdminKey(context.Background(), config)
+	for _, d := range diags {
+		if d.Severity() == diag.SeverityError {
+			return key, d.Detail()
+		}
+	}
+	return key, ""
+}
+
+// adminKeyCommandConfig returns a provider configuration running script with
+// sh as its admin_key_command. Each test passes a unique script, since
+// command output is cached for the lifetime of the process.
+func adminKeyCommandConfig(t *testing.T, script string) AnthropicProviderModel {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the test command uses sh")
+	}
+	return AnthropicProviderModel{AdminKeyCommand: keyOutputCommand("sh", "-c", script, t.Name())}
+}
+
+func TestResolveAdminKey(t *testing.T) {
+	t.Setenv("ANTHROPIC_ADMIN_KEY", "")
+	t.Setenv("ANTHROPIC_ADMIN_KEY_FILE", "")
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "admin-key")
+	if err := os.WriteFile(keyFile, []byte("  sk-ant-admin-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  AnthropicProviderModel
+		want    string
+		wantErr string
+	}{
+		{name: "admin_key", config: AnthropicProviderModel{AdminKey: types.StringValue("sk-ant-admin-config")}, want: "sk-ant-admin-config"},
+		{name: "admin_key_file", config: AnthropicProviderModel{AdminKeyFile: types.StringValue(keyFile)}, want: "sk-ant-admin-file"},
+		{name: "empty admin_key_file", config: AnthropicProviderModel{AdminKeyFile: types.StringValue(emptyFile)}, wantErr: "is empty"},
+		{name: "missing admin_key_file", config: AnthropicProviderModel{AdminKeyFile: types.StringValue(filepath.Join(dir, "missing"))}, wantErr: "unable to read admin key file"},
+		{name: "nothing", config: AnthropicProviderModel{}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveAdminKeyError(t, tt.config)
+			if tt.wantErr != "" {
+				if !strings.Contains(err, tt.wantErr) {
+					t.Errorf("resolveAdminKey error = %q, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != "" || got != tt.want {
+				t.Errorf("resolveAdminKey = %q, %q, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveAdminKeyFromEnvironment(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "admin-key")
+	if err := os.WriteFile(keyFile, []byte("sk-ant-admin-env-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("ANTHROPIC_ADMIN_KEY", "")
+	t.Setenv("ANTHROPIC_ADMIN_KEY_FILE", keyFile)
+	if got, err := resolveAdminKeyError(t, AnthropicProviderModel{}); got != "sk-ant-admin-env-file" || err != "" {
+		t.Errorf("resolveAdminKey with ANTHROPIC_ADMIN_KEY_FILE = %q, %q", got, err)
+	}
+
+	// ANTHROPIC_ADMIN_KEY takes precedence over the file, and the
+	// configuration over both
+	t.Setenv("ANTHROPIC_ADMIN_KEY", "sk-ant-admin-env")
+	if got, err := resolveAdminKeyError(t, AnthropicProviderModel{}); got != "sk-ant-admin-env" || err != "" {
+		t.Errorf("resolveAdminKey with ANTHROPIC_ADMIN_KEY = %q, %q", got, err)
+	}
+	config := AnthropicProviderModel{AdminKey: types.StringValue("sk-ant-admin-config")}
+	if got, err := resolveAdminKeyError(t, config); got != "sk-ant-admin-config" || err != "" {
+		t.Errorf("resolveAdminKey with admin_key = %q, %q", got, err)
+	}
+}
+
+func TestResolveAdminKeyCommand(t *testing.T) {
+	counter := filepath.Join(t.TempDir(), "runs")
+	config := adminKeyCommandConfig(t, `echo run >> "`+counter+`"; echo " sk-ant-admin-command "`)
+
+	for i := 0; i < 2; i++ {
+		if got, err := resolveAdminKeyError(t, config); got != "sk-ant-admin-command" || err != "" {
+			t.Fatalf("resolveAdminKey = %q, %q, want the key printed by the command", got, err)
+		}
+	}
+
+	runs, err := os.ReadFile(counter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(runs), "run"); n != 1 {
+		t.Errorf("admin_key_command ran %d times, want once", n)
+	}
+}
+
+func TestResolveAdminKeyCommandErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		timeout types.String
+		wantErr string
+	}{
+		{name: "failure", script: "echo denied >&2; exit 1", wantErr: "denied"},
+		{name: "no output", script: "true", wantErr: "printed no admin key"},
+		{name: "timeout", script: "sleep 5", timeout: types.StringValue("100ms"), wantErr: "timed out after 100ms"},
+		{name: "invalid timeout", script: "echo sk-ant-admin-x", timeout: types.StringValue("soon"), wantErr: "Expected a positive duration"},
+		{name: "negative timeout", script: "echo sk-ant-admin-x", timeout: types.StringValue("-1s"), wantErr: "Expected a positive duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := adminKeyCommandConfig(t, tt.script)
+			config.AdminKeyCommandTimeout = tt.timeout
+
+			if _, err := resolveAdminKeyError(t, config); !strings.Contains(err, tt.wantErr) {
+				t.Errorf("resolveAdminKey error = %q, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
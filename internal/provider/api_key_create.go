@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+)
+
+// apiKeyCreateAttempts is the number of times a create that failed
+// ambiguously is sent with the same idempotency key, so that the Admin API
+// returns the key it already created instead of creating another.
+const apiKeyCreateAttempts = 3
+
+// apiKeyCreateRetryDelay is the delay before the first retry of a create,
+// doubled for each further retry.
+var apiKeyCreateRetryDelay = 2 * time.Second
+
+// apiKeyAdoptionTimeout bounds the lookup of a key after an ambiguous failure.
+// The lookup runs even if the create was interrupted, so that an interrupted
+// apply does not leave an orphaned key behind.
+const apiKeyAdoptionTimeout = 30 * time.Second
+
+// createAPIKey creates an API key with an idempotency key. If the request
+// fails in a way that leaves it unclear whether the key was created, such as a
+// timeout, it is retried with the same idempotency key. If every attempt
+// fails, or the create is interrupted, it looks for a key with the same name in the same workspace created
+// since the first attempt started, and not created by another resource of
+// this provider, and adopts it instead of failing, so that the next apply
+// does not create a duplicate. Adopted keys are returned without their secret
+// key, which the Admin API only reveals in the create response.
+func createAPIKey(ctx context.Context, c adminapi.AdminAPI, owned *apiKeyRegistry, req *adminapi.CreateAPIKeyRequest) (*adminapi.APIKey, bool, error) {
+	idempotencyKey, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to generate idempotency key: %w", err)
+	}
+	createCtx := adminapi.ContextWithIdempotencyKey(ctx, idempotencyKey)
+
+	// The API reports creation times in whole seconds
+	start := time.Now().Truncate(time.Second)
+	delay := apiKeyCreateRetryDelay
+	for attempt := 1; ; attempt++ {
+		var apiKey *adminapi.APIKey
+		apiKey, err = c.CreateAPIKey(adminapi.ContextWithResendCount(createCtx, attempt-1), req)
+		if err == nil {
+			owned.register(apiKey.ID)
+			return apiKey, false, nil
+		}
+		// An interrupted create is not retried, but may have been applied
+		if ctx.Err() != nil {
+			break
+		}
+		if attempt == 1 && !adminapi.IsAmbiguous(err) {
+			return nil, false, err
+		}
+		if attempt == apiKeyCreateAttempts || !adminapi.IsAmbiguous(err) || !sleepContext(ctx, delay) {
+			break
+		}
+
+		tflog.Warn(ctx, "API key creation failed ambiguously, retrying with the same idempotency key", map[string]interface{}{
+			"name":         req.Name,
+			"workspace_id": req.WorkspaceID,
+			"attempt":      attempt,
+			"error":        err.Error(),
+		})
+		delay *= 2
+	}
+
+	tflog.Warn(ctx, "API key creation failed ambiguously, looking for a key to adopt", map[string]interface{}{
+		"name":         req.Name,
+		"workspace_id": req.WorkspaceID,
+		"error":        err.Error(),
+	})
+
+	lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), apiKeyAdoptionTimeout)
+	defer cancel()
+
+	apiKeys, listErr := c.ListAllAPIKeys(lookupCtx, "", req.WorkspaceID)
+	if listErr != nil {
+		return nil, false, fmt.Errorf("%w (unable to check whether the key was created anyway: %s)", err, listErr)
+	}
+
+	var candidates []adminapi.APIKey
+	for _, candidate := range apiKeys {
+		if candidate.Name != req.Name || candidate.WorkspaceID != req.WorkspaceID || candidate.Status == "archived" {
+			continue
+		}
+		if owned.contains(candidate.ID) {
+			continue
+		}
+		createdAt, parseErr := time.Parse(time.RFC3339, candidate.CreatedAt)
+		if parseErr != nil || createdAt.Before(start) {
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, false, err
+	case 1:
+		owned.register(candidates[0].ID)
+		return &candidates[0], true, nil
+	default:
+		ids := make([]string, len(candidates))
+		for i, candidate := range candidates {
+			ids[i] = candidate.ID
+		}
+		return nil, false, fmt.Errorf("%w (%d API keys named %q were created since the request was sent: %s; import the right one instead of creating another)", err, len(candidates), req.Name, strings.Join(ids, ", "))
+	}
+}
+
+// apiKeyAdoptedWarning explains that an API key was adopted without its secret.
+func apiKeyAdoptedWarning(name, id string) string {
+	return fmt.Sprintf("The request to create API key %q failed ambiguously, but API key %s with that name was created since the request was sent, so it was adopted instead of creating a duplicate. Its secret key was not received and cannot be retrieved. Replace the resource to create a new key if the secret is needed.", name, id)
+}
+
+// sleepContext waits for d and reports whether ctx was not done meanwhile.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// apiKeyRegistry records the API keys created or adopted by a provider
+// instance, so that a resource never adopts a key another resource created
+// concurrently with the same name. A nil registry records nothing.
+type apiKeyRegistry struct {
+	mu  sync.Mutex
+	ids map[string]bool
+}
+
+func newAPIKeyRegistry() *apiKeyRegistry {
+	return &apiKeyRegistry{ids: map[string]bool{}}
+}
+
+// register records the key with the given ID.
+func (k *apiKeyRegistry) register(id string) {
+	if k == nil {
+		return
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.ids[id] = true
+}
+
+// contains reports whether the key with the given ID was registered.
+func (k *apiKeyRegistry) contains(id string) bool {
+	if k == nil {
+		return false
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.ids[id]
+}
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi/fake"
+)
+
+// errTimeout is an ambiguous failure, as if the response never arrived.
+var errTimeout = errors.New("request failed: context deadline exceeded")
+
+// ambiguousCreates is a fake whose API key creates always fail ambiguously.
+// The first create is applied before failing if applyFirst is set.
+type ambiguousCreates struct {
+	*fake.Client
+	applyFirst bool
+	calls      int
+}
+
+func (a *ambiguousCreates) CreateAPIKey(ctx context.Context, req *adminapi.CreateAPIKeyRequest) (*adminapi.APIKey, error) {
+	a.calls++
+	if a.calls == 1 && a.applyFirst {
+		if _, err := a.Client.CreateAPIKey(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return nil, errTimeout
+}
+
+// createConcurrentKey creates a key named ci as if another request created it
+// while createAPIKey was running.
+func createConcurrentKey(t *testing.T, f *fake.Client) string {
+	t.Helper()
+
+	f.Now = func() time.Time { return time.Now().Add(time.Minute) }
+	defer func() { f.Now = time.Now }()
+
+	apiKey, err := f.CreateAPIKey(context.Background(), &adminapi.CreateAPIKeyRequest{Name: "ci"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return apiKey.ID
+}
+
+// withoutRetryDelay makes createAPIKey retry immediately for the duration of t.
+func withoutRetryDelay(t *testing.T) {
+	delay := apiKeyCreateRetryDelay
+	apiKeyCreateRetryDelay = 0
+	t.Cleanup(func() { apiKeyCreateRetryDelay = delay })
+}
+
+func TestCreateAPIKeyRetriesWithSameIdempotencyKey(t *testing.T) {
+	withoutRetryDelay(t)
+
+	var mu sync.Mutex
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			io.WriteString(w, `{"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`)
+			return
+		}
+		io.WriteString(w, `{"id":"apikey_1","name":"ci","status":"active","key":"sk-ant-api03-secret"}`)
+	}))
+	defer server.Close()
+
+	c := adminapi.NewClient("sk-ant-admin-test", adminapi.WithBaseURL(server.URL))
+	apiKey, adopted, err := createAPIKey(context.Background(), c, newAPIKeyRegistry(), &adminapi.CreateAPIKeyRequest{Name: "ci"})
+	if err != nil || adopted {
+		t.Fatalf("createAPIKey() = %v, %t, %v, want the retried create", apiKey, adopted, err)
+	}
+	if apiKey.Key != "sk-ant-api03-secret" {
+		t.Errorf("key = %q, want the secret from the retried create", apiKey.Key)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("idempotency keys = %q, want the same key on both attempts", keys)
+	}
+}
+
+func TestCreateAPIKeyAdoption(t *testing.T) {
+	tests := []struct {
+		name string
+		// setup creates keys before the create and returns the IDs of those
+		// created by other resources
+		setup      func(t *testing.T, f *fake.Client) []string
+		applyFirst bool
+		wantErr    string
+	}{
+		{
+			name:       "created anyway",
+			applyFirst: true,
+		},
+		{
+			name:    "not created",
+			wantErr: errTimeout.Error(),
+		},
+		{
+			name: "created by another resource",
+			setup: func(t *testing.T, f *fake.Client) []string {
+				return []string{createConcurrentKey(t, f)}
+			},
+			wantErr: errTimeout.Error(),
+		},
+		{
+			name: "created before the attempt",
+			setup: func(t *testing.T, f *fake.Client) []string {
+				f.Now = func() time.Time { return time.Now().Add(-2 * time.Second) }
+				defer func() { f.Now = time.Now }()
+				if _, err := f.CreateAPIKey(context.Background(), &adminapi.CreateAPIKeyRequest{Name: "ci"}); err != nil {
+					t.Fatal(err)
+				}
+				return nil
+			},
+			wantErr: errTimeout.Error(),
+		},
+		{
+			name: "several candidates",
+			setup: func(t *testing.T, f *fake.Client) []string {
+				createConcurrentKey(t, f)
+				return nil
+			},
+			applyFirst: true,
+			wantErr:    `2 API keys named "ci" were created`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withoutRetryDelay(t)
+
+			f := fake.NewClient()
+			owned := newAPIKeyRegistry()
+			if tt.setup != nil {
+				for _, id := range tt.setup(t, f) {
+					owned.register(id)
+				}
+			}
+
+			c := &ambiguousCreates{Client: f, applyFirst: tt.applyFirst}
+			apiKey, adopted, err := createAPIKey(context.Background(), c, owned, &adminapi.CreateAPIKeyRequest{Name: "ci"})
+
+			if c.calls != apiKeyCreateAttempts {
+				t.Errorf("create was attempted %d times, want %d", c.calls, apiKeyCreateAttempts)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("createAPIKey() = %v, %v, want an error containing %q", apiKey, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !adopted {
+				t.Fatalf("createAPIKey() = %v, %t, %v, want an adopted key", apiKey, adopted, err)
+			}
+			if apiKey.Key != "" {
+				t.Error("adopted key has a secret")
+			}
+			if !owned.contains(apiKey.ID) {
+				t.Error("adopted key was not registered")
+			}
+		})
+	}
+}
+
+func TestCreateAPIKeyUnambiguousFailure(t *testing.T) {
+	withoutRetryDelay(t)
+
+	f := fake.NewClient()
+	f.Errors["CreateAPIKey"] = &adminapi.HTTPError{StatusCode: http.StatusBadRequest, Body: "invalid"}
+	f.Errors["ListAllAPIKeys"] = fmt.Errorf("keys must not be listed after an unambiguous failure")
+
+	_, _, err := createAPIKey(context.Background(), f, newAPIKeyRegistry(), &adminapi.CreateAPIKeyRequest{Name: "ci"})
+	if err == nil || !strings.Contains(err.Error(), "status 400") {
+		t.Fatalf("createAPIKey() error = %v, want the 400 error", err)
+	}
+}
+
+func TestCreateAPIKeyInterrupted(t *testing.T) {
+	withoutRetryDelay(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// The interrupted create is not retried, but the key it created is adopted
+	c := &ambiguousCreates{Client: fake.NewClient(), applyFirst: true}
+	apiKey, adopted, err := createAPIKey(ctx, c, newAPIKeyRegistry(), &adminapi.CreateAPIKeyRequest{Name: "ci"})
+	if err != nil || !adopted {
+		t.Fatalf("createAPIKey() = %v, %t, %v, want an adopted key", apiKey, adopted, err)
+	}
+	if c.calls != 1 {
+		t.Errorf("create was attempted %d times, want once", c.calls)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/terraform-mars/terraform-provider-anthropic/internal/client"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -19,17 +18,18 @@ func NewAPIKeyDataSource() datasource.DataSource {
 
 // APIKeyDataSource defines the data source implementation.
 type APIKeyDataSource struct {
-	client *client.Client
+	providerData *AnthropicProviderData
 }
 
 // APIKeyDataSourceModel describes the data source data model.
 type APIKeyDataSourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	WorkspaceID types.String `tfsdk:"workspace_id"`
-	Status      types.String `tfsdk:"status"`
-	Hint        types.String `tfsdk:"hint"`
-	CreatedAt   types.String `tfsdk:"created_at"`
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	WorkspaceID  types.String `tfsdk:"workspace_id"`
+	Status       types.String `tfsdk:"status"`
+	Hint         types.String `tfsdk:"hint"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	Organization types.String `tfsdk:"organization"`
 }
 
 func (d *APIKeyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -64,6 +64,10 @@ func (d *APIKeyDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Description: "The timestamp when the API key was created.",
 				Computed:    true,
 			},
+			"organization": schema.StringAttribute{
+				Description: "The name of an entry in the provider's organizations map whose credentials are used for this data source. Defaults to the provider's admin_key.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -73,19 +77,22 @@ func (d *APIKeyDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*AnthropicProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.AnthropicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = c
+	d.providerData = providerData
 }
 
 func (d *APIKeyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "anthropic_api_key", "read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data APIKeyDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -94,7 +101,13 @@ func (d *APIKeyDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	apiKey, err := d.client.GetAPIKey(ctx, data.ID.ValueString())
+	c, diags := d.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiKey, err := c.GetAPIKey(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API key: %s", err))
 		return
@@ -3,19 +3,29 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/terraform-mars/terraform-provider-anthropic/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &APIKeyResource{}
 var _ resource.ResourceWithImportState = &APIKeyResource{}
+var _ resource.ResourceWithValidateConfig = &APIKeyResource{}
 
 func NewAPIKeyResource() resource.Resource {
 	return &APIKeyResource{}
@@ -23,18 +33,29 @@ func NewAPIKeyResource() resource.Resource {
 
 // APIKeyResource defines the resource implementation.
 type APIKeyResource struct {
-	client *client.Client
+	providerData *AnthropicProviderData
 }
 
 // APIKeyResourceModel describes the resource data model.
 type APIKeyResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	WorkspaceID types.String `tfsdk:"workspace_id"`
-	Status      types.String `tfsdk:"status"`
-	Hint        types.String `tfsdk:"hint"`
-	Key         types.String `tfsdk:"key"`
-	CreatedAt   types.String `tfsdk:"created_at"`
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	WorkspaceID  types.String `tfsdk:"workspace_id"`
+	Status       types.String `tfsdk:"status"`
+	Hint         types.String `tfsdk:"hint"`
+	Key          types.String `tfsdk:"key"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	Organization types.String `tfsdk:"organization"`
+
+	PGPKey         types.String `tfsdk:"pgp_key"`
+	EncryptedKey   types.String `tfsdk:"encrypted_key"`
+	KeyFingerprint types.String `tfsdk:"key_fingerprint"`
+
+	KeyOutput types.Object `tfsdk:"key_output"`
+	KeySHA256 types.String `tfsdk:"key_sha256"`
+
+	OnDestroy          types.String `tfsdk:"on_destroy"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 func (r *APIKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -73,7 +94,7 @@ func (r *APIKeyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Computed:    true,
 			},
 			"key": schema.StringAttribute{
-				Description: "The full API key value. Only available immediately after creation.",
+				Description: "The full API key value. Only available immediately after creation, and null when pgp_key or key_output is set.",
 				Computed:    true,
 				Sensitive:   true,
 				PlanModifiers: []planmodifier.String{
@@ -87,6 +108,88 @@ func (r *APIKeyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"pgp_key": schema.StringAttribute{
+				Description: "A PGP public key used to encrypt the API key before it is stored in state, given as an ASCII-armored key, a base64-encoded binary key, or file:<path> to read either from disk. When set, only encrypted_key and key_fingerprint are stored and key is null.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"encrypted_key": schema.StringAttribute{
+				Description: "The API key encrypted with pgp_key, base64-encoded. Decrypt it with `base64 --decode | gpg --decrypt`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key_fingerprint": schema.StringAttribute{
+				Description: "The fingerprint of the PGP key used to encrypt encrypted_key.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key_output": schema.SingleNestedAttribute{
+				Description: "Delivers the API key once to a local sink when it is created instead of storing it in state. Exactly one of file, pipe or command must be set. When set, key is null and only key_sha256 is stored.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"file": schema.StringAttribute{
+						Description: "Path of a file to write the key to. The file is replaced atomically and created with 0600 permissions.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.ExactlyOneOf(
+								path.MatchRelative().AtParent().AtName("pipe"),
+								path.MatchRelative().AtParent().AtName("command"),
+							),
+						},
+					},
+					"pipe": schema.StringAttribute{
+						Description: "Path of an existing named pipe to write the key to. A reader must already be attached to the pipe.",
+						Optional:    true,
+					},
+					"command": schema.ListAttribute{
+						Description: "A helper command and its arguments. The command is executed once and receives the key on standard input.",
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(path.MatchRoot("pgp_key")),
+				},
+			},
+			"key_sha256": schema.StringAttribute{
+				Description: "The hex-encoded SHA-256 fingerprint of the API key delivered through key_output.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"on_destroy": schema.StringAttribute{
+				Description: "What happens to the API key when this resource is destroyed: archive it, deactivate it (keeping it inactive for review), or abandon it (only remove it from state). Defaults to the provider's on_destroy.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(onDestroyArchive, onDestroyDeactivate, onDestroyAbandon),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether destroying or replacing the API key is prevented. Must be set to false in a separate apply before the API key can be destroyed. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"organization": schema.StringAttribute{
+				Description: "The name of an entry in the provider's organizations map whose credentials are used for this resource. Defaults to the provider's admin_key. Changing it forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 		},
 	}
 }
@@ -96,19 +199,40 @@ func (r *APIKeyResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*AnthropicProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.AnthropicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.providerData = providerData
+}
+
+func (r *APIKeyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data APIKeyResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.PGPKey.IsNull() || data.PGPKey.IsUnknown() {
+		return
+	}
+
+	if _, err := loadPGPEncryptionKey(data.PGPKey.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("pgp_key"), "Invalid PGP Key", err.Error())
+	}
 }
 
 func (r *APIKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "anthropic_api_key", "create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data APIKeyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -117,18 +241,69 @@ func (r *APIKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	createReq := &client.CreateAPIKeyRequest{
+	resp.Diagnostics.Append(r.providerData.checkWritable("create API key")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_api_key", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createReq := &adminapi.CreateAPIKeyRequest{
 		Name: data.Name.ValueString(),
 	}
 	if !data.WorkspaceID.IsNull() {
 		createReq.WorkspaceID = data.WorkspaceID.ValueString()
 	}
 
-	apiKey, err := r.client.CreateAPIKey(ctx, createReq)
+	// Check the PGP key up front so a bad key never leaves an orphaned API key
+	var pgpEntity *openpgp.Entity
+	if !data.PGPKey.IsNull() {
+		var err error
+		pgpEntity, err = loadPGPEncryptionKey(data.PGPKey.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("pgp_key"), "Invalid PGP Key", err.Error())
+			return
+		}
+	}
+
+	var keyOutput *APIKeyOutputModel
+	if !data.KeyOutput.IsNull() {
+		keyOutput = &APIKeyOutputModel{}
+		resp.Diagnostics.Append(data.KeyOutput.As(ctx, keyOutput, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Check the sink up front so a bad sink never loses the key
+		if err := checkKeyOutput(ctx, *keyOutput); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("key_output"), "Invalid Key Output", err.Error())
+			return
+		}
+	}
+
+	apiKey, adopted, err := createAPIKey(ctx, c, r.providerData.apiKeys, createReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create API key: %s", err))
 		return
 	}
+	// An adopted key has no secret to deliver, so fail the create but save the
+	// key: the resource is tainted and the next apply replaces it with a key
+	// whose secret is delivered
+	switch {
+	case adopted && (keyOutput != nil || pgpEntity != nil):
+		resp.Diagnostics.AddError(
+			"API Key Not Delivered",
+			fmt.Sprintf("The request to create API key %q failed ambiguously, but API key %s with that name was created since the request was sent, so it was adopted instead of creating a duplicate. Its secret key was not received, so it could not be delivered through key_output or encrypted with pgp_key. The key was saved in state and marked tainted, so the next apply replaces it with a new key and delivers that key's secret.", apiKey.Name, apiKey.ID),
+		)
+	case adopted:
+		resp.Diagnostics.AddWarning("API Key Adopted", apiKeyAdoptedWarning(apiKey.Name, apiKey.ID))
+	}
 
 	data.ID = types.StringValue(apiKey.ID)
 	data.Name = types.StringValue(apiKey.Name)
@@ -137,10 +312,25 @@ func (r *APIKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 	data.CreatedAt = types.StringValue(apiKey.CreatedAt)
 
 	// The key is only returned on creation
-	if apiKey.Key != "" {
+	data.Key = types.StringNull()
+	data.EncryptedKey = types.StringNull()
+	data.KeyFingerprint = types.StringNull()
+	data.KeySHA256 = types.StringNull()
+	if apiKey.Key != "" && keyOutput != nil {
+		data.KeySHA256 = types.StringValue(keySHA256(apiKey.Key))
+		if err := deliverKey(ctx, *keyOutput, apiKey.Key); err != nil {
+			resp.Diagnostics.AddError("Key Delivery Error", fmt.Sprintf("API key %s was created but could not be delivered: %s", apiKey.ID, err))
+		}
+	} else if apiKey.Key != "" && pgpEntity != nil {
+		encryptedKey, fingerprint, err := encryptWithPGPKey(pgpEntity, apiKey.Key)
+		if err != nil {
+			resp.Diagnostics.AddError("Encryption Error", fmt.Sprintf("API key %s was created but could not be encrypted: %s", apiKey.ID, err))
+		} else {
+			data.EncryptedKey = types.StringValue(encryptedKey)
+			data.KeyFingerprint = types.StringValue(fingerprint)
+		}
+	} else if apiKey.Key != "" {
 		data.Key = types.StringValue(apiKey.Key)
-	} else {
-		data.Key = types.StringNull()
 	}
 
 	if apiKey.WorkspaceID != "" {
@@ -151,6 +341,9 @@ func (r *APIKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *APIKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "anthropic_api_key", "read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data APIKeyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -159,7 +352,13 @@ func (r *APIKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	apiKey, err := r.client.GetAPIKey(ctx, data.ID.ValueString())
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiKey, err := c.GetAPIKey(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API key: %s", err))
 		return
@@ -177,10 +376,18 @@ func (r *APIKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	// The key is not returned on read, preserve existing value
 	// data.Key stays as-is from state
 
+	// Imported resources have no deletion_protection in state yet
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *APIKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "anthropic_api_key", "update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data APIKeyResourceModel
 	var state APIKeyResourceModel
 
@@ -191,7 +398,20 @@ func (r *APIKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	updateReq := &client.UpdateAPIKeyRequest{}
+	resp.Diagnostics.Append(r.providerData.checkWritable("update API key")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_api_key", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateReq := &adminapi.UpdateAPIKeyRequest{}
 
 	// Check if name changed
 	if !data.Name.Equal(state.Name) {
@@ -203,7 +423,18 @@ func (r *APIKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		updateReq.Status = data.Status.ValueString()
 	}
 
-	apiKey, err := r.client.UpdateAPIKey(ctx, data.ID.ValueString(), updateReq)
+	// Changes to local-only attributes such as on_destroy need no API call
+	if updateReq.Name == "" && updateReq.Status == "" {
+		if data.Status.IsUnknown() {
+			data.Status = state.Status
+		}
+		data.Hint = state.Hint
+		data.Key = state.Key
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	apiKey, err := c.UpdateAPIKey(ctx, data.ID.ValueString(), updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update API key: %s", err))
 		return
@@ -220,6 +451,9 @@ func (r *APIKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *APIKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "anthropic_api_key", "delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data APIKeyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -228,13 +462,72 @@ func (r *APIKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.DeleteAPIKey(ctx, data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete API key: %s", err))
+	resp.Diagnostics.Append(r.providerData.checkWritable("destroy API key")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_api_key", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Deletion Protection Enabled",
+			fmt.Sprintf("API key %s has deletion_protection enabled. Set deletion_protection to false and apply that change before destroying or replacing it.", data.ID.ValueString()),
+		)
+		return
+	}
+
+	switch r.providerData.onDestroyFor(data.OnDestroy) {
+	case onDestroyAbandon:
+		resp.Diagnostics.AddWarning(
+			"API Key Abandoned",
+			fmt.Sprintf("API key %s was removed from state but left unchanged in the organization.", data.ID.ValueString()),
+		)
+	case onDestroyDeactivate:
+		_, err := c.UpdateAPIKey(ctx, data.ID.ValueString(), &adminapi.UpdateAPIKeyRequest{Status: "inactive"})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to deactivate API key: %s", err))
+			return
+		}
+	default:
+		err := c.DeleteAPIKey(ctx, data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete API key: %s", err))
+			return
+		}
+	}
 }
 
 func (r *APIKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx, span := startSpan(ctx, "anthropic_api_key", "import")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	// Import ID format: [<organization>:]api_key_id or name:<workspace>/<key-name>
+	organization, importID := r.providerData.splitImportOrganization(req.ID)
+
+	// Only lookups need the API, raw IDs are imported as-is
+	id := importID
+	if strings.HasPrefix(importID, importPrefixName) {
+		c, diags := r.providerData.clientFor(organization)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var err error
+		id, err = resolveAPIKeyImportID(ctx, c, importID)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Unable to resolve API key import ID: %s", err))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization"), organization)...)
 }
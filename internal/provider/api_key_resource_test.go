@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi/fake"
+)
+
+// newAPIKeyPlan returns the plan of an API key named ci with every optional
+// attribute unset.
+func newAPIKeyPlan(t *testing.T, r *APIKeyResource) APIKeyResourceModel {
+	t.Helper()
+
+	keyOutput := resourceSchema(t, r).Attributes["key_output"].GetType().(basetypes.ObjectType)
+	return APIKeyResourceModel{
+		ID:                 types.StringUnknown(),
+		Name:               types.StringValue("ci"),
+		WorkspaceID:        types.StringNull(),
+		Status:             types.StringUnknown(),
+		Hint:               types.StringUnknown(),
+		Key:                types.StringUnknown(),
+		CreatedAt:          types.StringUnknown(),
+		Organization:       types.StringNull(),
+		PGPKey:             types.StringNull(),
+		EncryptedKey:       types.StringUnknown(),
+		KeyFingerprint:     types.StringUnknown(),
+		KeyOutput:          types.ObjectNull(keyOutput.AttrTypes),
+		KeySHA256:          types.StringUnknown(),
+		OnDestroy:          types.StringNull(),
+		DeletionProtection: types.BoolValue(false),
+	}
+}
+
+func TestAPIKeyCreateWithPGPKey(t *testing.T) {
+	entity := newPGPEntity(t)
+	r := &APIKeyResource{providerData: newProviderData(fake.NewClient())}
+
+	plan := newAPIKeyPlan(t, r)
+	plan.PGPKey = types.StringValue(armoredPGPPublicKey(t, entity))
+
+	resp := testCreate(t, r, planOf(t, r, plan))
+	requireNoErrors(t, "Create", resp.Diagnostics)
+
+	var data APIKeyResourceModel
+	getState(t, resp.State, &data)
+	if !data.Key.IsNull() {
+		t.Error("key is stored in state in plain text")
+	}
+	if want := "sk-ant-api03-" + data.ID.ValueString(); decryptWithPGPEntity(t, entity, data.EncryptedKey.ValueString()) != want {
+		t.Errorf("encrypted_key does not decrypt to the created key %s", want)
+	}
+}
+
+func TestAPIKeyCreateWithUnusablePGPKey(t *testing.T) {
+	entity := newPGPEntity(t)
+	entity.Subkeys = nil
+	f := fake.NewClient()
+	r := &APIKeyResource{providerData: newProviderData(f)}
+
+	plan := newAPIKeyPlan(t, r)
+	plan.PGPKey = types.StringValue(armoredPGPPublicKey(t, entity))
+
+	resp := testCreate(t, r, planOf(t, r, plan))
+	if got := summaries(resp.Diagnostics, diag.SeverityError); len(got) != 1 || got[0] != "Invalid PGP Key" {
+		t.Errorf("Create errors = %v, want Invalid PGP Key", got)
+	}
+	checkAPIKeyCount(t, f, 0)
+}
+
+// checkAPIKeyCount reports whether the organization has other than want API keys.
+func checkAPIKeyCount(t *testing.T, f *fake.Client, want int) {
+	t.Helper()
+
+	apiKeys, err := f.ListAllAPIKeys(context.Background(), "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apiKeys) != want {
+		t.Errorf("organization has %d API keys, want %d", len(apiKeys), want)
+	}
+}
+
+// withKeyOutput sets the key_output of plan.
+func withKeyOutput(t *testing.T, plan *APIKeyResourceModel, output APIKeyOutputModel) {
+	t.Helper()
+
+	value, diags := types.ObjectValueFrom(context.Background(), plan.KeyOutput.AttributeTypes(context.Background()), output)
+	requireNoErrors(t, "key_output", diags)
+	plan.KeyOutput = value
+}
+
+func TestAPIKeyCreateWithKeyOutput(t *testing.T) {
+	r := &APIKeyResource{providerData: newProviderData(fake.NewClient())}
+	path := filepath.Join(t.TempDir(), "key")
+
+	plan := newAPIKeyPlan(t, r)
+	output := newKeyOutput()
+	output.File = types.StringValue(path)
+	withKeyOutput(t, &plan, output)
+
+	resp := testCreate(t, r, planOf(t, r, plan))
+	requireNoErrors(t, "Create", resp.Diagnostics)
+
+	var data APIKeyResourceModel
+	getState(t, resp.State, &data)
+	if !data.Key.IsNull() {
+		t.Error("key is stored in state in plain text")
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "sk-ant-api03-" + data.ID.ValueString(); string(contents) != want {
+		t.Errorf("key file contains %q, want %q", contents, want)
+	}
+	if got, want := data.KeySHA256.ValueString(), keySHA256(string(contents)); got != want {
+		t.Errorf("key_sha256 = %s, want %s", got, want)
+	}
+}
+
+func TestAPIKeyCreateWithUnusableKeyOutput(t *testing.T) {
+	f := fake.NewClient()
+	r := &APIKeyResource{providerData: newProviderData(f)}
+
+	plan := newAPIKeyPlan(t, r)
+	output := newKeyOutput()
+	output.File = types.StringValue(filepath.Join(t.TempDir(), "missing", "key"))
+	withKeyOutput(t, &plan, output)
+
+	resp := testCreate(t, r, planOf(t, r, plan))
+	if got := summaries(resp.Diagnostics, diag.SeverityError); len(got) != 1 || got[0] != "Invalid Key Output" {
+		t.Errorf("Create errors = %v, want Invalid Key Output", got)
+	}
+	checkAPIKeyCount(t, f, 0)
+}
+
+// newAPIKeyState creates an API key in f and returns its state.
+func newAPIKeyState(t *testing.T, r *APIKeyResource, f *fake.Client) APIKeyResourceModel {
+	t.Helper()
+
+	apiKey, err := f.CreateAPIKey(context.Background(), &adminapi.CreateAPIKeyRequest{Name: "ci"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := newAPIKeyPlan(t, r)
+	data.ID = types.StringValue(apiKey.ID)
+	data.Status = types.StringValue(apiKey.Status)
+	data.Hint = types.StringValue(apiKey.Hint)
+	data.Key = types.StringValue(apiKey.Key)
+	data.CreatedAt = types.StringValue(apiKey.CreatedAt)
+	data.EncryptedKey = types.StringNull()
+	data.KeyFingerprint = types.StringNull()
+	data.KeySHA256 = types.StringNull()
+	return data
+}
+
+func TestAPIKeyDeleteOnDestroy(t *testing.T) {
+	tests := []struct {
+		name            string
+		providerDefault string
+		onDestroy       types.String
+		wantStatus      string
+		wantWarnings    []string
+	}{
+		{name: "default", onDestroy: types.StringNull()},
+		{name: "archive", providerDefault: onDestroyAbandon, onDestroy: types.StringValue(onDestroyArchive)},
+		{name: "deactivate", onDestroy: types.StringValue(onDestroyDeactivate), wantStatus: "inactive"},
+		{name: "abandon", onDestroy: types.StringValue(onDestroyAbandon), wantStatus: "active", wantWarnings: []string{"API Key Abandoned"}},
+		{name: "provider deactivate", providerDefault: onDestroyDeactivate, onDestroy: types.StringNull(), wantStatus: "inactive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := fake.NewClient()
+			r := &APIKeyResource{providerData: newProviderData(f)}
+			if tt.providerDefault != "" {
+				r.providerData.onDestroy = tt.providerDefault
+			}
+
+			data := newAPIKeyState(t, r, f)
+			data.OnDestroy = tt.onDestroy
+
+			resp := testDelete(t, r, stateOf(t, r, data))
+			requireNoErrors(t, "Delete", resp.Diagnostics)
+			if got := summaries(resp.Diagnostics, diag.SeverityWarning); !reflect.DeepEqual(got, tt.wantWarnings) {
+				t.Errorf("Delete warnings = %v, want %v", got, tt.wantWarnings)
+			}
+
+			// The fake deletes archived keys entirely
+			apiKey, err := f.GetAPIKey(context.Background(), data.ID.ValueString())
+			if tt.wantStatus == "" {
+				if err == nil {
+					t.Errorf("API key status = %s, want it archived", apiKey.Status)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if apiKey.Status != tt.wantStatus {
+				t.Errorf("API key status = %s, want %s", apiKey.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestAPIKeyDeleteProtected(t *testing.T) {
+	f := fake.NewClient()
+	r := &APIKeyResource{providerData: newProviderData(f)}
+
+	data := newAPIKeyState(t, r, f)
+	data.DeletionProtection = types.BoolValue(true)
+
+	resp := testDelete(t, r, stateOf(t, r, data))
+	if got := summaries(resp.Diagnostics, diag.SeverityError); !reflect.DeepEqual(got, []string{"Deletion Protection Enabled"}) {
+		t.Errorf("Delete errors = %v, want Deletion Protection Enabled", got)
+	}
+	checkAPIKeyCount(t, f, 1)
+}
+
+func TestAPIKeyCreateAdoptedWithKeyOutput(t *testing.T) {
+	withoutRetryDelay(t)
+
+	c := &ambiguousCreates{Client: fake.NewClient(), applyFirst: true}
+	r := &APIKeyResource{providerData: newProviderData(c)}
+	path := filepath.Join(t.TempDir(), "key")
+
+	plan := newAPIKeyPlan(t, r)
+	output := newKeyOutput()
+	output.File = types.StringValue(path)
+	withKeyOutput(t, &plan, output)
+
+	// The adopted key is saved, so that it is replaced rather than orphaned
+	resp := testCreate(t, r, planOf(t, r, plan))
+	if got := summaries(resp.Diagnostics, diag.SeverityError); !reflect.DeepEqual(got, []string{"API Key Not Delivered"}) {
+		t.Errorf("Create errors = %v, want API Key Not Delivered", got)
+	}
+
+	var data APIKeyResourceModel
+	getState(t, resp.State, &data)
+	if data.ID.ValueString() == "" || !data.KeySHA256.IsNull() {
+		t.Errorf("Create saved id %s and key_sha256 %s, want the adopted key without a fingerprint", data.ID, data.KeySHA256)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("key file was written for an adopted key: %v", err)
+	}
+}
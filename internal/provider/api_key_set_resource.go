@@ -0,0 +1,567 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &APIKeySetResource{}
+var _ resource.ResourceWithModifyPlan = &APIKeySetResource{}
+
+func NewAPIKeySetResource() resource.Resource {
+	return &APIKeySetResource{}
+}
+
+// APIKeySetResource defines the resource implementation.
+type APIKeySetResource struct {
+	providerData *AnthropicProviderData
+}
+
+// APIKeySetResourceModel describes the resource data model.
+type APIKeySetResourceModel struct {
+	ID           types.String                   `tfsdk:"id"`
+	WorkspaceID  types.String                   `tfsdk:"workspace_id"`
+	Keys         map[string]APIKeySetEntryModel `tfsdk:"keys"`
+	APIKeys      types.Map                      `tfsdk:"api_keys"`
+	Organization types.String                   `tfsdk:"organization"`
+}
+
+// APIKeySetEntryModel describes the desired settings of a single key in the set.
+type APIKeySetEntryModel struct {
+	Name   types.String `tfsdk:"name"`
+	Status types.String `tfsdk:"status"`
+}
+
+// APIKeySetKeyModel describes a single key managed by the set.
+type APIKeySetKeyModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Status    types.String `tfsdk:"status"`
+	Hint      types.String `tfsdk:"hint"`
+	Key       types.String `tfsdk:"key"`
+	CreatedAt types.String `tfsdk:"created_at"`
+}
+
+// apiKeySetKeyAttrTypes are the attribute types of the api_keys map elements.
+var apiKeySetKeyAttrTypes = map[string]attr.Type{
+	"id":         types.StringType,
+	"name":       types.StringType,
+	"status":     types.StringType,
+	"hint":       types.StringType,
+	"key":        types.StringType,
+	"created_at": types.StringType,
+}
+
+func (r *APIKeySetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_key_set"
+}
+
+func (r *APIKeySetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a set of Anthropic API keys as a single resource. Keys are identified by their map key, so adding, renaming, deactivating or removing a key only affects that entry.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the API key set.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workspace_id": schema.StringAttribute{
+				Description: "The ID of the workspace the API keys belong to. If not specified, the keys are organization-wide.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"keys": schema.MapNestedAttribute{
+				Description: "The API keys to manage, keyed by a stable logical name.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the API key. Defaults to the map key.",
+							Optional:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the API key (active, inactive). Defaults to active.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("active", "inactive"),
+							},
+						},
+					},
+				},
+			},
+			"api_keys": schema.MapNestedAttribute{
+				Description: "The API keys managed by this set, keyed by the same names as keys.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The unique identifier of the API key.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the API key.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the API key.",
+							Computed:    true,
+						},
+						"hint": schema.StringAttribute{
+							Description: "The last 4 characters of the API key for identification.",
+							Computed:    true,
+						},
+						"key": schema.StringAttribute{
+							Description: "The full API key value. Only available for keys created by this resource.",
+							Computed:    true,
+							Sensitive:   true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "The timestamp when the API key was created.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"organization": schema.StringAttribute{
+				Description: "The name of an entry in the provider's organizations map whose credentials are used for this resource. Defaults to the provider's admin_key. Changing it forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *APIKeySetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*AnthropicProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.AnthropicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *APIKeySetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx, span := startSpan(ctx, "anthropic_api_key_set", "plan")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	// Nothing to plan on create or destroy; replacements are planned as creates
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state APIKeySetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Keys == nil {
+		return
+	}
+
+	current, diags := r.getAPIKeys(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the existing keys known so that changing one entry does not make
+	// every key unknown, and leave only the keys to be created unknown
+	elements := map[string]attr.Value{}
+	for name, entry := range plan.Keys {
+		key, ok := current[name]
+		if !ok || entry.Name.IsUnknown() || entry.Status.IsUnknown() {
+			elements[name] = types.ObjectUnknown(apiKeySetKeyAttrTypes)
+			continue
+		}
+
+		key.Name = types.StringValue(apiKeySetEntryName(name, entry))
+		key.Status = types.StringValue(apiKeySetEntryStatus(entry))
+		value, diags := types.ObjectValueFrom(ctx, apiKeySetKeyAttrTypes, key)
+		resp.Diagnostics.Append(diags...)
+		elements[name] = value
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	value, diags := types.MapValue(types.ObjectType{AttrTypes: apiKeySetKeyAttrTypes}, elements)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("api_keys"), value)...)
+}
+
+func (r *APIKeySetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "anthropic_api_key_set", "create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data APIKeySetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.providerData.checkWritable("create API key set")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to generate API key set ID: %s", err))
+		return
+	}
+	data.ID = types.StringValue(id)
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_api_key_set", data.ID.ValueString())
+
+	apiKeys := map[string]APIKeySetKeyModel{}
+	failures := map[string]error{}
+	for _, name := range sortedKeys(data.Keys) {
+		key, adopted, err := r.createKey(ctx, c, data.WorkspaceID, name, data.Keys[name])
+		if key != nil {
+			apiKeys[name] = *key
+		}
+		if adopted {
+			resp.Diagnostics.AddWarning("API Key Adopted", apiKeyAdoptedWarning(key.Name.ValueString(), key.ID.ValueString()))
+		}
+		if err != nil {
+			failures[name] = err
+		}
+	}
+
+	// Nothing was created, so there is nothing to save
+	if len(apiKeys) == 0 && len(failures) > 0 {
+		for _, name := range sortedKeys(failures) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create API key %q: %s", name, failures[name]))
+		}
+		return
+	}
+
+	// Failures are reported as warnings once some keys exist: an error would
+	// taint the set and the next apply would replace every key created here.
+	// The missing keys are created by the next apply instead.
+	for _, name := range sortedKeys(failures) {
+		resp.Diagnostics.AddWarning(
+			"API Key Not Created",
+			fmt.Sprintf("Unable to create API key %q: %s. The other keys of the set were saved, and the next apply will retry this key.", name, failures[name]),
+		)
+	}
+
+	resp.Diagnostics.Append(r.setAPIKeys(ctx, &data, apiKeys)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APIKeySetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "anthropic_api_key_set", "read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data APIKeySetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := r.getAPIKeys(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiKeys := map[string]APIKeySetKeyModel{}
+	for name, key := range current {
+		apiKey, err := c.GetAPIKey(ctx, key.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API key %q: %s", name, err))
+			return
+		}
+
+		// Archived keys are gone for good, drop them so they get recreated
+		if apiKey.Status == "archived" {
+			delete(data.Keys, name)
+			continue
+		}
+
+		key.Name = types.StringValue(apiKey.Name)
+		key.Status = types.StringValue(apiKey.Status)
+		key.Hint = types.StringValue(apiKey.Hint)
+		apiKeys[name] = key
+
+		// Reflect out-of-band changes in keys while keeping omitted defaults null
+		entry := data.Keys[name]
+		if !entry.Name.IsNull() || apiKey.Name != name {
+			entry.Name = types.StringValue(apiKey.Name)
+		}
+		if !entry.Status.IsNull() || apiKey.Status != "active" {
+			entry.Status = types.StringValue(apiKey.Status)
+		}
+		data.Keys[name] = entry
+	}
+
+	resp.Diagnostics.Append(r.setAPIKeys(ctx, &data, apiKeys)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APIKeySetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "anthropic_api_key_set", "update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data APIKeySetResourceModel
+	var state APIKeySetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.providerData.checkWritable("update API key set")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_api_key_set", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiKeys, diags := r.getAPIKeys(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Archive keys that are no longer part of the set
+	for _, name := range sortedKeys(apiKeys) {
+		if _, ok := data.Keys[name]; ok {
+			continue
+		}
+		if err := c.DeleteAPIKey(ctx, apiKeys[name].ID.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete API key %q: %s", name, err))
+			break
+		}
+		delete(apiKeys, name)
+	}
+
+	for _, name := range sortedKeys(data.Keys) {
+		if resp.Diagnostics.HasError() {
+			break
+		}
+
+		entry := data.Keys[name]
+		existing, ok := apiKeys[name]
+		if !ok {
+			key, adopted, err := r.createKey(ctx, c, data.WorkspaceID, name, entry)
+			if key != nil {
+				apiKeys[name] = *key
+			}
+			if adopted {
+				resp.Diagnostics.AddWarning("API Key Adopted", apiKeyAdoptedWarning(key.Name.ValueString(), key.ID.ValueString()))
+			}
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create API key %q: %s", name, err))
+			}
+			continue
+		}
+
+		updateReq := &adminapi.UpdateAPIKeyRequest{}
+		if desired := apiKeySetEntryName(name, entry); desired != existing.Name.ValueString() {
+			updateReq.Name = desired
+		}
+		if desired := apiKeySetEntryStatus(entry); desired != existing.Status.ValueString() {
+			updateReq.Status = desired
+		}
+		if updateReq.Name == "" && updateReq.Status == "" {
+			continue
+		}
+
+		apiKey, err := c.UpdateAPIKey(ctx, existing.ID.ValueString(), updateReq)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update API key %q: %s", name, err))
+			continue
+		}
+
+		// Preserve the key from state since it's not returned on update
+		existing.Name = types.StringValue(apiKey.Name)
+		existing.Status = types.StringValue(apiKey.Status)
+		existing.Hint = types.StringValue(apiKey.Hint)
+		apiKeys[name] = existing
+	}
+
+	resp.Diagnostics.Append(r.setAPIKeys(ctx, &data, apiKeys)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APIKeySetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "anthropic_api_key_set", "delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data APIKeySetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.providerData.checkWritable("destroy API key set")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_api_key_set", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiKeys, diags := r.getAPIKeys(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, name := range sortedKeys(apiKeys) {
+		if err := c.DeleteAPIKey(ctx, apiKeys[name].ID.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete API key %q: %s", name, err))
+		}
+	}
+}
+
+// createKey creates a single API key of the set and applies its initial status.
+// The returned key is non-nil whenever the key exists remotely, even on error,
+// and adopted reports whether it was adopted after an ambiguous failure.
+func (r *APIKeySetResource) createKey(ctx context.Context, c adminapi.AdminAPI, workspaceID types.String, name string, entry APIKeySetEntryModel) (*APIKeySetKeyModel, bool, error) {
+	createReq := &adminapi.CreateAPIKeyRequest{
+		Name: apiKeySetEntryName(name, entry),
+	}
+	if !workspaceID.IsNull() {
+		createReq.WorkspaceID = workspaceID.ValueString()
+	}
+
+	apiKey, adopted, err := createAPIKey(ctx, c, r.providerData.apiKeys, createReq)
+	if err != nil {
+		return nil, false, err
+	}
+
+	key := &APIKeySetKeyModel{
+		ID:        types.StringValue(apiKey.ID),
+		Name:      types.StringValue(apiKey.Name),
+		Status:    types.StringValue(apiKey.Status),
+		Hint:      types.StringValue(apiKey.Hint),
+		Key:       types.StringNull(),
+		CreatedAt: types.StringValue(apiKey.CreatedAt),
+	}
+	if apiKey.Key != "" {
+		key.Key = types.StringValue(apiKey.Key)
+	}
+
+	if status := apiKeySetEntryStatus(entry); status != apiKey.Status {
+		updated, err := c.UpdateAPIKey(ctx, apiKey.ID, &adminapi.UpdateAPIKeyRequest{Status: status})
+		if err != nil {
+			return key, adopted, err
+		}
+		key.Status = types.StringValue(updated.Status)
+	}
+
+	return key, adopted, nil
+}
+
+// getAPIKeys decodes the api_keys attribute, treating null and unknown as empty.
+func (r *APIKeySetResource) getAPIKeys(ctx context.Context, data APIKeySetResourceModel) (map[string]APIKeySetKeyModel, diag.Diagnostics) {
+	apiKeys := map[string]APIKeySetKeyModel{}
+	if data.APIKeys.IsNull() || data.APIKeys.IsUnknown() {
+		return apiKeys, nil
+	}
+
+	diags := data.APIKeys.ElementsAs(ctx, &apiKeys, false)
+	return apiKeys, diags
+}
+
+// setAPIKeys encodes apiKeys into the api_keys attribute of data.
+func (r *APIKeySetResource) setAPIKeys(ctx context.Context, data *APIKeySetResourceModel, apiKeys map[string]APIKeySetKeyModel) diag.Diagnostics {
+	value, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: apiKeySetKeyAttrTypes}, apiKeys)
+	data.APIKeys = value
+	return diags
+}
+
+// apiKeySetEntryName returns the desired API key name of a set entry.
+func apiKeySetEntryName(name string, entry APIKeySetEntryModel) string {
+	if entry.Name.IsNull() || entry.Name.IsUnknown() {
+		return name
+	}
+	return entry.Name.ValueString()
+}
+
+// apiKeySetEntryStatus returns the desired API key status of a set entry.
+func apiKeySetEntryStatus(entry APIKeySetEntryModel) string {
+	if entry.Status.IsNull() || entry.Status.IsUnknown() {
+		return "active"
+	}
+	return entry.Status.ValueString()
+}
+
+// sortedKeys returns the keys of m in lexical order so API calls are deterministic.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
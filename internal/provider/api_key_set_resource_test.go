@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi/fake"
+)
+
+// rejectedCreates is a fake that rejects creating the API keys with the given
+// names, an unambiguous failure that is not retried.
+type rejectedCreates struct {
+	*fake.Client
+	names map[string]bool
+}
+
+func (r *rejectedCreates) CreateAPIKey(ctx context.Context, req *adminapi.CreateAPIKeyRequest) (*adminapi.APIKey, error) {
+	if r.names[req.Name] {
+		return nil, &adminapi.HTTPError{StatusCode: http.StatusBadRequest, Body: "rejected"}
+	}
+	return r.Client.CreateAPIKey(ctx, req)
+}
+
+func newAPIKeySetPlan(names ...string) APIKeySetResourceModel {
+	keys := map[string]APIKeySetEntryModel{}
+	for _, name := range names {
+		keys[name] = APIKeySetEntryModel{Name: types.StringNull(), Status: types.StringNull()}
+	}
+	return APIKeySetResourceModel{
+		ID:           types.StringUnknown(),
+		WorkspaceID:  types.StringNull(),
+		Keys:         keys,
+		APIKeys:      types.MapUnknown(types.ObjectType{AttrTypes: apiKeySetKeyAttrTypes}),
+		Organization: types.StringNull(),
+	}
+}
+
+// apiKeySetNames returns the names of the keys saved in the api_keys of data.
+func apiKeySetNames(t *testing.T, data APIKeySetResourceModel) []string {
+	t.Helper()
+
+	var apiKeys map[string]APIKeySetKeyModel
+	requireNoErrors(t, "api_keys", data.APIKeys.ElementsAs(context.Background(), &apiKeys, false))
+	return sortedKeys(apiKeys)
+}
+
+func TestAPIKeySetPartialCreate(t *testing.T) {
+	client := &rejectedCreates{Client: fake.NewClient(), names: map[string]bool{"deploy": true}}
+	r := &APIKeySetResource{providerData: newProviderData(client)}
+
+	resp := testCreate(t, r, planOf(t, r, newAPIKeySetPlan("ci", "deploy")))
+	requireNoErrors(t, "Create", resp.Diagnostics)
+	if got, want := summaries(resp.Diagnostics, diag.SeverityWarning), []string{"API Key Not Created"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Create warnings = %v, want %v", got, want)
+	}
+
+	var created APIKeySetResourceModel
+	getState(t, resp.State, &created)
+	if got, want := apiKeySetNames(t, created), []string{"ci"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("api_keys after create = %v, want %v", got, want)
+	}
+
+	// The next plan with the same configuration creates the missing key only
+	client.names = nil
+	plan := testModifyPlan(t, r, planOf(t, r, created), resp.State)
+	requireNoErrors(t, "ModifyPlan", plan.Diagnostics)
+
+	update := testUpdate(t, r, plan.Plan, resp.State)
+	requireNoErrors(t, "Update", update.Diagnostics)
+
+	var updated APIKeySetResourceModel
+	getState(t, update.State, &updated)
+	if got, want := apiKeySetNames(t, updated), []string{"ci", "deploy"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("api_keys after update = %v, want %v", got, want)
+	}
+
+	apiKeys, err := client.ListAPIKeys(context.Background(), 0, "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apiKeys.Data) != 2 {
+		t.Errorf("organization has %d API keys, want 2", len(apiKeys.Data))
+	}
+}
+
+func TestAPIKeySetCreateNothingCreated(t *testing.T) {
+	client := &rejectedCreates{Client: fake.NewClient(), names: map[string]bool{"ci": true, "deploy": true}}
+	r := &APIKeySetResource{providerData: newProviderData(client)}
+
+	resp := testCreate(t, r, planOf(t, r, newAPIKeySetPlan("ci", "deploy")))
+	if got := len(summaries(resp.Diagnostics, diag.SeverityError)); got != 2 {
+		t.Errorf("Create reported %d errors, want 2: %v", got, resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("Create saved state although no key was created")
+	}
+}
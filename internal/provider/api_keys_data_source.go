@@ -7,7 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/terraform-mars/terraform-provider-anthropic/internal/client"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -19,14 +19,15 @@ func NewAPIKeysDataSource() datasource.DataSource {
 
 // APIKeysDataSource defines the data source implementation.
 type APIKeysDataSource struct {
-	client *client.Client
+	providerData *AnthropicProviderData
 }
 
 // APIKeysDataSourceModel describes the data source data model.
 type APIKeysDataSourceModel struct {
-	WorkspaceID types.String    `tfsdk:"workspace_id"`
-	Status      types.String    `tfsdk:"status"`
-	APIKeys     []APIKeyModel   `tfsdk:"api_keys"`
+	WorkspaceID  types.String  `tfsdk:"workspace_id"`
+	Status       types.String  `tfsdk:"status"`
+	APIKeys      []APIKeyModel `tfsdk:"api_keys"`
+	Organization types.String  `tfsdk:"organization"`
 }
 
 // APIKeyModel describes a single API key in the list.
@@ -87,6 +88,10 @@ func (d *APIKeysDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 					},
 				},
 			},
+			"organization": schema.StringAttribute{
+				Description: "The name of an entry in the provider's organizations map whose credentials are used for this data source. Defaults to the provider's admin_key.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -96,19 +101,22 @@ func (d *APIKeysDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*AnthropicProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.AnthropicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = c
+	d.providerData = providerData
 }
 
 func (d *APIKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "anthropic_api_keys", "read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data APIKeysDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -117,6 +125,12 @@ func (d *APIKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
+	c, diags := d.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get filter values
 	var workspaceID, status string
 	if !data.WorkspaceID.IsNull() {
@@ -127,11 +141,11 @@ func (d *APIKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	// Fetch all API keys with pagination
-	var allAPIKeys []client.APIKey
+	var allAPIKeys []adminapi.APIKey
 	var afterID string
 
 	for {
-		apiKeys, err := d.client.ListAPIKeys(ctx, 100, "", afterID, status, workspaceID)
+		apiKeys, err := c.ListAPIKeys(ctx, 100, "", afterID, status, workspaceID)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list API keys: %s", err))
 			return
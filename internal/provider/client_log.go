@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// clientLogSubsystem is the tflog subsystem used for Admin API traffic. Its
+// level follows TF_LOG_PROVIDER unless TF_LOG_PROVIDER_ANTHROPIC_CLIENT is set.
+const clientLogSubsystem = "anthropic_client"
+
+// clientLogger writes the logs of the Admin API client to clientLogSubsystem.
+// The client masks secrets before logging, according to log_redaction.
+type clientLogger struct{}
+
+func (clientLogger) Debug(ctx context.Context, msg string, fields map[string]interface{}) {
+	tflog.SubsystemDebug(clientLogContext(ctx), clientLogSubsystem, msg, fields)
+}
+
+func (clientLogger) Trace(ctx context.Context, msg string, fields map[string]interface{}) {
+	tflog.SubsystemTrace(clientLogContext(ctx), clientLogSubsystem, msg, fields)
+}
+
+// clientLogContext returns ctx with clientLogSubsystem configured.
+func clientLogContext(ctx context.Context) context.Context {
+	return tflog.NewSubsystem(ctx, clientLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER", clientLogSubsystem))
+}
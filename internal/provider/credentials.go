@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+)
+
+// Prefixes of Anthropic keys. Only Admin API keys can call the Admin API.
+const (
+	adminKeyPrefix = "sk-ant-admin"
+	apiKeyPrefix   = "sk-ant-api"
+)
+
+// validateAdminKeyFormat checks that key looks like an Admin API key.
+func validateAdminKeyFormat(key string) error {
+	switch {
+	case strings.HasPrefix(key, adminKeyPrefix):
+		return nil
+	case strings.HasPrefix(key, apiKeyPrefix):
+		return errors.New("the key is a regular API key (sk-ant-api...), but the Admin API requires an Admin API key (sk-ant-admin...). Admin keys are created in the Anthropic Console under Settings > Admin Keys")
+	default:
+		return errors.New("the key does not look like an Anthropic Admin API key, which starts with sk-ant-admin")
+	}
+}
+
+// checkCredentials verifies that c can authenticate with the Admin API and
+// returns the organization its key belongs to.
+func checkCredentials(ctx context.Context, c *adminapi.Client) (*adminapi.Organization, error) {
+	organization, err := c.GetOrganization(ctx)
+	if err == nil {
+		return organization, nil
+	}
+
+	var httpErr *adminapi.HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, fmt.Errorf("the Admin API rejected the key, it may be revoked or mistyped: %w", err)
+		case http.StatusForbidden:
+			return nil, fmt.Errorf("the key is not allowed to use the Admin API: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("unable to reach the Admin API at %s: %w", c.BaseURL, err)
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+)
+
+func TestValidateAdminKeyFormat(t *testing.T) {
+	tests := []struct {
+		key     string
+		wantErr string
+	}{
+		{key: "sk-ant-admin01-abc"},
+		{key: "sk-ant-api03-abc", wantErr: "regular API key"},
+		{key: "not-a-key", wantErr: "does not look like an Anthropic Admin API key"},
+	}
+
+	for _, tt := range tests {
+		err := validateAdminKeyFormat(tt.key)
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("validateAdminKeyFormat(%q) = %s", tt.key, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("validateAdminKeyFormat(%q) = %v, want an error containing %q", tt.key, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		status   int
+		wantErr  string
+		wantCall bool
+	}{
+		{name: "valid", key: "sk-ant-admin01-abc", status: http.StatusOK, wantCall: true},
+		{name: "api key", key: "sk-ant-api03-abc", status: http.StatusOK, wantErr: "regular API key"},
+		{name: "revoked", key: "sk-ant-admin01-abc", status: http.StatusUnauthorized, wantErr: "may be revoked or mistyped", wantCall: true},
+		{name: "forbidden", key: "sk-ant-admin01-abc", status: http.StatusForbidden, wantErr: "not allowed to use the Admin API", wantCall: true},
+		{name: "unreachable", key: "sk-ant-admin01-abc", status: http.StatusBadGateway, wantErr: "unable to reach the Admin API", wantCall: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				if r.URL.Path != "/v1/organizations/me" {
+					t.Errorf("preflight requested %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+				io.WriteString(w, `{"id":"org_1","type":"organization","name":"Example"}`)
+			}))
+			defer server.Close()
+
+			c := adminapi.NewClient(tt.key, adminapi.WithBaseURL(server.URL))
+			diags := validateCredentials(context.Background(), c, path.Root("admin_key"))
+
+			if called != tt.wantCall {
+				t.Errorf("preflight request made = %t, want %t", called, tt.wantCall)
+			}
+			if tt.wantErr == "" {
+				requireNoErrors(t, "validateCredentials", diags)
+				return
+			}
+			errs := diags.Errors()
+			if len(errs) != 1 || !strings.Contains(errs[0].Detail(), tt.wantErr) {
+				t.Fatalf("validateCredentials = %v, want an error containing %q", diags, tt.wantErr)
+			}
+			if d, ok := errs[0].(diag.DiagnosticWithPath); !ok || !d.Path().Equal(path.Root("admin_key")) {
+				t.Errorf("validateCredentials error is not reported on admin_key")
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+)
+
+// Import ID prefixes that select a lookup instead of a raw ID.
+const (
+	importPrefixName  = "name:"
+	importPrefixEmail = "email:"
+)
+
+// splitImportOrganization splits an import ID of the form <organization>:<id>,
+// where organization is an entry of the provider's organizations map, into the
+// organization and the rest of the ID. Any other ID is returned as-is with a
+// null organization.
+func (d *AnthropicProviderData) splitImportOrganization(importID string) (types.String, string) {
+	name, rest, ok := strings.Cut(importID, ":")
+	if !ok || d == nil {
+		return types.StringNull(), importID
+	}
+	if _, configured := d.organizations[name]; !configured {
+		return types.StringNull(), importID
+	}
+	return types.StringValue(name), rest
+}
+
+// resolveWorkspaceImportID resolves a workspace import ID of the form
+// name:<workspace-name> to a workspace ID. Any other ID is returned as-is.
+func resolveWorkspaceImportID(ctx context.Context, c adminapi.AdminAPI, importID string) (string, error) {
+	name, ok := strings.CutPrefix(importID, importPrefixName)
+	if !ok {
+		return importID, nil
+	}
+
+	workspace, err := findWorkspace(ctx, c, name)
+	if err != nil {
+		return "", err
+	}
+	return workspace.ID, nil
+}
+
+// resolveAPIKeyImportID resolves an API key import ID of the form
+// name:<workspace>/<key-name> to an API key ID. The workspace may be given by
+// ID or name. Any other ID is returned as-is.
+func resolveAPIKeyImportID(ctx context.Context, c adminapi.AdminAPI, importID string) (string, error) {
+	ref, ok := strings.CutPrefix(importID, importPrefixName)
+	if !ok {
+		return importID, nil
+	}
+
+	workspaceRef, keyName, ok := strings.Cut(ref, "/")
+	if !ok || workspaceRef == "" || keyName == "" {
+		return "", fmt.Errorf("expected import ID format: name:<workspace>/<key-name>, got: %s", importID)
+	}
+
+	workspace, err := findWorkspace(ctx, c, workspaceRef)
+	if err != nil {
+		return "", err
+	}
+
+	apiKeys, err := c.ListAllAPIKeys(ctx, "", workspace.ID)
+	if err != nil {
+		return "", fmt.Errorf("unable to list API keys: %w", err)
+	}
+
+	var matches []string
+	for _, apiKey := range apiKeys {
+		if apiKey.Name == keyName && apiKey.Status != "archived" {
+			matches = append(matches, apiKey.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no API key named %q found in workspace %s", keyName, workspace.ID)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("multiple API keys named %q found in workspace %s (%s), import by ID instead", keyName, workspace.ID, strings.Join(matches, ", "))
+	}
+}
+
+// resolveInviteImportID resolves an invite import ID of the form
+// email:<address> to an invite ID, preferring pending invites. Any other ID is
+// returned as-is.
+func resolveInviteImportID(ctx context.Context, c adminapi.AdminAPI, importID string) (string, error) {
+	email, ok := strings.CutPrefix(importID, importPrefixEmail)
+	if !ok {
+		return importID, nil
+	}
+
+	invites, err := c.ListAllInvites(ctx)
+	if err != nil {
+		return "", fmt.Errorf("unable to list invites: %w", err)
+	}
+
+	var matches, pending []string
+	for _, invite := range invites {
+		if !strings.EqualFold(invite.Email, email) {
+			continue
+		}
+		matches = append(matches, invite.ID)
+		if invite.Status == "pending" {
+			pending = append(pending, invite.ID)
+		}
+	}
+
+	if len(pending) > 0 {
+		matches = pending
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no invite found for %q", email)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("multiple invites found for %q (%s), import by ID instead", email, strings.Join(matches, ", "))
+	}
+}
+
+// findOrganizationMemberByEmail looks up an organization member by email,
+// ignoring case. It returns nil if no member has the email.
+func findOrganizationMemberByEmail(ctx context.Context, c adminapi.AdminAPI, email string) (*adminapi.OrganizationMember, error) {
+	members, err := c.ListAllOrganizationMembers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list organization members: %w", err)
+	}
+
+	for _, member := range members {
+		if strings.EqualFold(member.Email, email) {
+			return &member, nil
+		}
+	}
+	return nil, nil
+}
+
+// findWorkspace looks up a workspace by ID or name. Only active workspaces
+// match by name.
+func findWorkspace(ctx context.Context, c adminapi.AdminAPI, ref string) (*adminapi.Workspace, error) {
+	workspaces, err := c.ListAllWorkspaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list workspaces: %w", err)
+	}
+
+	var matches []adminapi.Workspace
+	for _, workspace := range workspaces {
+		if workspace.ID == ref {
+			return &workspace, nil
+		}
+		// Archived workspaces keep their name, which may have been reused since
+		if workspace.Name == ref && workspace.ArchivedAt == "" {
+			matches = append(matches, workspace)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no workspace found with ID or name %q", ref)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("multiple workspaces named %q found, use the workspace ID instead", ref)
+	}
+}
@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi/fake"
+)
+
+// importFixture is an organization with an active production workspace, an
+// archived workspace whose name was reused, and API keys and invites.
+type importFixture struct {
+	f          *fake.Client
+	production string
+	archived   string
+	ciKey      string
+}
+
+func newImportFixture(t *testing.T) importFixture {
+	t.Helper()
+	ctx := context.Background()
+	f := fake.NewClient()
+
+	archived, err := f.CreateWorkspace(ctx, &adminapi.CreateWorkspaceRequest{Name: "production"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.ArchiveWorkspace(ctx, archived.ID); err != nil {
+		t.Fatal(err)
+	}
+	production, err := f.CreateWorkspace(ctx, &adminapi.CreateWorkspaceRequest{Name: "production"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"staging", "staging"} {
+		if _, err := f.CreateWorkspace(ctx, &adminapi.CreateWorkspaceRequest{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ciKey, err := f.CreateAPIKey(ctx, &adminapi.CreateAPIKeyRequest{Name: "ci", WorkspaceID: production.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	retired, err := f.CreateAPIKey(ctx, &adminapi.CreateAPIKeyRequest{Name: "retired", WorkspaceID: production.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.DeleteAPIKey(ctx, retired.ID); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"shared", "shared"} {
+		if _, err := f.CreateAPIKey(ctx, &adminapi.CreateAPIKeyRequest{Name: name, WorkspaceID: production.ID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return importFixture{f: f, production: production.ID, archived: archived.ID, ciKey: ciKey.ID}
+}
+
+func TestResolveWorkspaceImportID(t *testing.T) {
+	x := newImportFixture(t)
+
+	tests := []struct {
+		importID string
+		want     string
+		wantErr  string
+	}{
+		{importID: "wrkspc_raw", want: "wrkspc_raw"},
+		// The archived workspace named production is ignored
+		{importID: "name:production", want: x.production},
+		{importID: "name:" + x.archived, want: x.archived},
+		{importID: "name:staging", wantErr: `multiple workspaces named "staging"`},
+		{importID: "name:missing", wantErr: `no workspace found with ID or name "missing"`},
+	}
+
+	for _, tt := range tests {
+		got, err := resolveWorkspaceImportID(context.Background(), x.f, tt.importID)
+		checkImportID(t, tt.importID, got, err, tt.want, tt.wantErr)
+	}
+}
+
+func TestResolveAPIKeyImportID(t *testing.T) {
+	x := newImportFixture(t)
+
+	tests := []struct {
+		importID string
+		want     string
+		wantErr  string
+	}{
+		{importID: "apikey_raw", want: "apikey_raw"},
+		{importID: "name:production/ci", want: x.ciKey},
+		{importID: "name:" + x.production + "/ci", want: x.ciKey},
+		{importID: "name:production/retired", wantErr: `no API key named "retired"`},
+		{importID: "name:production/shared", wantErr: `multiple API keys named "shared"`},
+		{importID: "name:production", wantErr: "expected import ID format: name:<workspace>/<key-name>"},
+		{importID: "name:/ci", wantErr: "expected import ID format"},
+		{importID: "name:missing/ci", wantErr: `no workspace found with ID or name "missing"`},
+	}
+
+	for _, tt := range tests {
+		got, err := resolveAPIKeyImportID(context.Background(), x.f, tt.importID)
+		checkImportID(t, tt.importID, got, err, tt.want, tt.wantErr)
+	}
+}
+
+func TestResolveInviteImportID(t *testing.T) {
+	ctx := context.Background()
+	f := fake.NewClient()
+
+	ada, err := f.CreateInvite(ctx, &adminapi.CreateInviteRequest{Email: "Ada@example.com", Role: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := f.CreateInvite(ctx, &adminapi.CreateInviteRequest{Email: "bob@example.com", Role: "user"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		importID string
+		want     string
+		wantErr  string
+	}{
+		{importID: "invite_raw", want: "invite_raw"},
+		{importID: "email:ada@EXAMPLE.com", want: ada.ID},
+		{importID: "email:bob@example.com", wantErr: `multiple invites found for "bob@example.com"`},
+		{importID: "email:eve@example.com", wantErr: `no invite found for "eve@example.com"`},
+	}
+
+	for _, tt := range tests {
+		got, err := resolveInviteImportID(ctx, f, tt.importID)
+		checkImportID(t, tt.importID, got, err, tt.want, tt.wantErr)
+	}
+}
+
+// checkImportID reports a resolved import ID that does not match the expected
+// ID or error.
+func checkImportID(t *testing.T, importID, got string, err error, want, wantErr string) {
+	t.Helper()
+
+	if wantErr != "" {
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("resolving %q = %q, %v, want an error containing %q", importID, got, err, wantErr)
+		}
+		return
+	}
+	if err != nil || got != want {
+		t.Errorf("resolving %q = %q, %v, want %q", importID, got, err, want)
+	}
+}
+
+func TestSplitImportOrganization(t *testing.T) {
+	d := newProviderData(fake.NewClient())
+	d.organizations["sandbox"] = fake.NewClient()
+
+	tests := []struct {
+		importID         string
+		wantOrganization types.String
+		wantID           string
+	}{
+		{importID: "wrkspc_1", wantOrganization: types.StringNull(), wantID: "wrkspc_1"},
+		{importID: "sandbox:wrkspc_1", wantOrganization: types.StringValue("sandbox"), wantID: "wrkspc_1"},
+		{importID: "sandbox:name:production", wantOrganization: types.StringValue("sandbox"), wantID: "name:production"},
+		{importID: "name:production", wantOrganization: types.StringNull(), wantID: "name:production"},
+		{importID: "email:ada@example.com", wantOrganization: types.StringNull(), wantID: "email:ada@example.com"},
+	}
+
+	for _, tt := range tests {
+		organization, id := d.splitImportOrganization(tt.importID)
+		if !organization.Equal(tt.wantOrganization) || id != tt.wantID {
+			t.Errorf("splitImportOrganization(%q) = %s, %q, want %s, %q", tt.importID, organization, id, tt.wantOrganization, tt.wantID)
+		}
+	}
+}
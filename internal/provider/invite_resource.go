@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -12,7 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/terraform-mars/terraform-provider-anthropic/internal/client"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -25,18 +26,19 @@ func NewInviteResource() resource.Resource {
 
 // InviteResource defines the resource implementation.
 type InviteResource struct {
-	client *client.Client
+	providerData *AnthropicProviderData
 }
 
 // InviteResourceModel describes the resource data model.
 type InviteResourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	Email     types.String `tfsdk:"email"`
-	Role      types.String `tfsdk:"role"`
-	Status    types.String `tfsdk:"status"`
-	CreatedAt types.String `tfsdk:"created_at"`
-	ExpiresAt types.String `tfsdk:"expires_at"`
-	InviterID types.String `tfsdk:"inviter_id"`
+	ID           types.String `tfsdk:"id"`
+	Email        types.String `tfsdk:"email"`
+	Role         types.String `tfsdk:"role"`
+	Status       types.String `tfsdk:"status"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	ExpiresAt    types.String `tfsdk:"expires_at"`
+	InviterID    types.String `tfsdk:"inviter_id"`
+	Organization types.String `tfsdk:"organization"`
 }
 
 func (r *InviteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -93,6 +95,13 @@ func (r *InviteResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"organization": schema.StringAttribute{
+				Description: "The name of an entry in the provider's organizations map whose credentials are used for this resource. Defaults to the provider's admin_key. Changing it forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 		},
 	}
 }
@@ -102,19 +111,22 @@ func (r *InviteResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*AnthropicProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.AnthropicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.providerData = providerData
 }
 
 func (r *InviteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "anthropic_invite", "create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data InviteResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -123,7 +135,20 @@ func (r *InviteResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	invite, err := r.client.CreateInvite(ctx, &client.CreateInviteRequest{
+	resp.Diagnostics.Append(r.providerData.checkWritable("create invite")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_invite", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	invite, err := c.CreateInvite(ctx, &adminapi.CreateInviteRequest{
 		Email: data.Email.ValueString(),
 		Role:  data.Role.ValueString(),
 	})
@@ -149,6 +174,9 @@ func (r *InviteResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *InviteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "anthropic_invite", "read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data InviteResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -157,7 +185,13 @@ func (r *InviteResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	invite, err := r.client.GetInvite(ctx, data.ID.ValueString())
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	invite, err := c.GetInvite(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read invite: %s", err))
 		return
@@ -176,6 +210,9 @@ func (r *InviteResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *InviteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "anthropic_invite", "update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	// Invites cannot be updated, only created or deleted
 	resp.Diagnostics.AddError(
 		"Update Not Supported",
@@ -184,6 +221,9 @@ func (r *InviteResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *InviteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "anthropic_invite", "delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data InviteResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -192,7 +232,20 @@ func (r *InviteResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.DeleteInvite(ctx, data.ID.ValueString())
+	resp.Diagnostics.Append(r.providerData.checkWritable("destroy invite")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_invite", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := c.DeleteInvite(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete invite: %s", err))
 		return
@@ -200,5 +253,29 @@ func (r *InviteResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *InviteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx, span := startSpan(ctx, "anthropic_invite", "import")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	// Import ID format: [<organization>:]invite_id or email:<address>
+	organization, importID := r.providerData.splitImportOrganization(req.ID)
+
+	// Only lookups need the API, raw IDs are imported as-is
+	id := importID
+	if strings.HasPrefix(importID, importPrefixEmail) {
+		c, diags := r.providerData.clientFor(organization)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var err error
+		id, err = resolveInviteImportID(ctx, c, importID)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Unable to resolve invite import ID: %s", err))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization"), organization)...)
 }
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// APIKeyOutputModel describes where a newly created API key is delivered.
+type APIKeyOutputModel struct {
+	File    types.String `tfsdk:"file"`
+	Pipe    types.String `tfsdk:"pipe"`
+	Command types.List   `tfsdk:"command"`
+}
+
+// deliverKey writes key to the sink described by output.
+func deliverKey(ctx context.Context, output APIKeyOutputModel, key string) error {
+	switch {
+	case !output.File.IsNull():
+		return writeKeyFile(output.File.ValueString(), key)
+	case !output.Pipe.IsNull():
+		return writeKeyPipe(output.Pipe.ValueString(), key)
+	case !output.Command.IsNull():
+		var command []string
+		if diags := output.Command.ElementsAs(ctx, &command, false); diags.HasError() {
+			return fmt.Errorf("invalid command")
+		}
+		return runKeyCommand(ctx, command, key)
+	default:
+		return fmt.Errorf("one of file, pipe or command must be set")
+	}
+}
+
+// checkKeyOutput checks that the sink described by output can receive a key,
+// so that a misconfigured sink fails before the API key is created instead of
+// losing the key. The pipe is not opened, since closing it would signal the
+// end of input to its reader.
+func checkKeyOutput(ctx context.Context, output APIKeyOutputModel) error {
+	switch {
+	case !output.File.IsNull():
+		dir := filepath.Dir(output.File.ValueString())
+		info, err := os.Stat(dir)
+		if err != nil {
+			return fmt.Errorf("unable to use key file directory: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not a directory", dir)
+		}
+		return nil
+	case !output.Pipe.IsNull():
+		return checkKeyPipe(output.Pipe.ValueString())
+	case !output.Command.IsNull():
+		var command []string
+		if diags := output.Command.ElementsAs(ctx, &command, false); diags.HasError() {
+			return fmt.Errorf("invalid command")
+		}
+		if len(command) == 0 || command[0] == "" {
+			return fmt.Errorf("command must not be empty")
+		}
+		if _, err := exec.LookPath(command[0]); err != nil {
+			return fmt.Errorf("unable to find command: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("one of file, pipe or command must be set")
+	}
+}
+
+// keySHA256 returns the hex-encoded SHA-256 fingerprint of key.
+func keySHA256(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeKeyFile atomically writes key to path with 0600 permissions by
+// renaming a temporary file from the same directory into place.
+func writeKeyFile(path, key string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("unable to create temporary key file: %w", err)
+	}
+	tmpPath := f.Name()
+	defer os.Remove(tmpPath)
+
+	if err := f.Chmod(0o600); err != nil {
+		f.Close()
+		return fmt.Errorf("unable to set key file permissions: %w", err)
+	}
+	if _, err := f.WriteString(key); err != nil {
+		f.Close()
+		return fmt.Errorf("unable to write key file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("unable to write key file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("unable to write key file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("unable to move key file into place: %w", err)
+	}
+	return nil
+}
+
+// writeKeyPipe writes key to an existing named pipe that already has a reader.
+func writeKeyPipe(path, key string) error {
+	f, err := openKeyPipe(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.WriteString(key); err != nil {
+		f.Close()
+		return fmt.Errorf("unable to write to named pipe: %w", err)
+	}
+	return f.Close()
+}
+
+// runKeyCommand executes command with key on its standard input.
+func runKeyCommand(ctx context.Context, command []string, key string) error {
+	if len(command) == 0 || command[0] == "" {
+		return fmt.Errorf("command must not be empty")
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdin = strings.NewReader(key)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("command %q failed: %w: %s", command[0], err, msg)
+		}
+		return fmt.Errorf("command %q failed: %w", command[0], err)
+	}
+	return nil
+}
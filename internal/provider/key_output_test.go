@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// keyOutputCommand returns a key_output.command list.
+func keyOutputCommand(command ...string) types.List {
+	elements := make([]types.String, len(command))
+	for i, arg := range command {
+		elements[i] = types.StringValue(arg)
+	}
+	list, _ := types.ListValueFrom(context.Background(), types.StringType, elements)
+	return list
+}
+
+func newKeyOutput() APIKeyOutputModel {
+	return APIKeyOutputModel{File: types.StringNull(), Pipe: types.StringNull(), Command: types.ListNull(types.StringType)}
+}
+
+func TestCheckKeyOutput(t *testing.T) {
+	dir := t.TempDir()
+	regular := filepath.Join(dir, "regular")
+	if err := os.WriteFile(regular, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		output  func(*APIKeyOutputModel)
+		wantErr string
+	}{
+		{name: "file", output: func(o *APIKeyOutputModel) { o.File = types.StringValue(filepath.Join(dir, "key")) }},
+		{name: "file in missing directory", output: func(o *APIKeyOutputModel) { o.File = types.StringValue(filepath.Join(dir, "missing", "key")) }, wantErr: "unable to use key file directory"},
+		{name: "file in a file", output: func(o *APIKeyOutputModel) { o.File = types.StringValue(filepath.Join(regular, "key")) }, wantErr: "is not a directory"},
+		{name: "pipe that is a regular file", output: func(o *APIKeyOutputModel) { o.Pipe = types.StringValue(regular) }, wantErr: "pipe"},
+		{name: "command", output: func(o *APIKeyOutputModel) { o.Command = keyOutputCommand("go", "version") }},
+		{name: "missing command", output: func(o *APIKeyOutputModel) { o.Command = keyOutputCommand("anthropic-missing-helper") }, wantErr: "unable to find command"},
+		{name: "empty command", output: func(o *APIKeyOutputModel) { o.Command = keyOutputCommand("") }, wantErr: "command must not be empty"},
+		{name: "nothing", output: func(*APIKeyOutputModel) {}, wantErr: "one of file, pipe or command must be set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := newKeyOutput()
+			tt.output(&output)
+
+			err := checkKeyOutput(context.Background(), output)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("checkKeyOutput: %s", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("checkKeyOutput = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeliverKeyToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	output := newKeyOutput()
+	output.File = types.StringValue(path)
+	if err := deliverKey(context.Background(), output, "sk-ant-api03-secret"); err != nil {
+		t.Fatalf("deliverKey: %s", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(contents) != "sk-ant-api03-secret" {
+		t.Errorf("key file contains %q, want the key", contents)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0o600 {
+		t.Errorf("key file permissions = %v, want 0600", info.Mode().Perm())
+	}
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("key file directory has %d entries, want 1", len(entries))
+	}
+}
+
+func TestDeliverKeyToCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test command uses sh")
+	}
+
+	path := filepath.Join(t.TempDir(), "key")
+	output := newKeyOutput()
+	output.Command = keyOutputCommand("sh", "-c", `cat > "$1"`, "sh", path)
+	if err := deliverKey(context.Background(), output, "sk-ant-api03-secret"); err != nil {
+		t.Fatalf("deliverKey: %s", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(contents) != "sk-ant-api03-secret" {
+		t.Errorf("command received %q, want the key", contents)
+	}
+
+	output.Command = keyOutputCommand("sh", "-c", "echo denied >&2; exit 3")
+	err = deliverKey(context.Background(), output, "sk-ant-api03-secret")
+	if err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("deliverKey = %v, want an error with the command's stderr", err)
+	}
+}
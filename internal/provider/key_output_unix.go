@@ -0,0 +1,39 @@
+//go:build !windows
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// checkKeyPipe checks that path is a named pipe.
+func checkKeyPipe(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("unable to open named pipe: %w", err)
+	}
+	if info.Mode()&os.ModeNamedPipe == 0 {
+		return fmt.Errorf("%s is not a named pipe", path)
+	}
+	return nil
+}
+
+// openKeyPipe opens a named pipe for writing without blocking when there is
+// no reader on the other end.
+func openKeyPipe(path string) (*os.File, error) {
+	if err := checkKeyPipe(path); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|syscall.O_NONBLOCK, 0)
+	if errors.Is(err, syscall.ENXIO) {
+		return nil, fmt.Errorf("named pipe %s has no reader", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to open named pipe: %w", err)
+	}
+	return f, nil
+}
@@ -0,0 +1,51 @@
+//go:build !windows
+
+package provider
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDeliverKeyToPipe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pipe")
+	if err := syscall.Mkfifo(path, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	output := newKeyOutput()
+	output.Pipe = types.StringValue(path)
+	if err := checkKeyOutput(context.Background(), output); err != nil {
+		t.Fatalf("checkKeyOutput: %s", err)
+	}
+
+	// Without a reader the key is not written anywhere
+	err := deliverKey(context.Background(), output, "sk-ant-api03-secret")
+	if err == nil || !strings.Contains(err.Error(), "has no reader") {
+		t.Fatalf("deliverKey without a reader = %v, want an error", err)
+	}
+
+	reader, err := os.OpenFile(path, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	if err := deliverKey(context.Background(), output, "sk-ant-api03-secret"); err != nil {
+		t.Fatalf("deliverKey: %s", err)
+	}
+	received, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(received) != "sk-ant-api03-secret" {
+		t.Errorf("pipe received %q, want the key", received)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build windows
+
+package provider
+
+import (
+	"fmt"
+	"os"
+)
+
+// checkKeyPipe is not supported on Windows, where named pipes are not files.
+func checkKeyPipe(path string) error {
+	return fmt.Errorf("key_output.pipe is not supported on Windows")
+}
+
+// openKeyPipe is not supported on Windows, where named pipes are not files.
+func openKeyPipe(path string) (*os.File, error) {
+	return nil, fmt.Errorf("key_output.pipe is not supported on Windows")
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+// pgpKeyFilePrefix marks a pgp_key value that references a key file on disk.
+const pgpKeyFilePrefix = "file:"
+
+// encryptWithPGPKey encrypts plaintext for entity and returns the
+// base64-encoded message together with the fingerprint of the key used.
+func encryptWithPGPKey(entity *openpgp.Entity, plaintext string) (string, string, error) {
+	var buf bytes.Buffer
+	w, err := openpgp.Encrypt(&buf, []*openpgp.Entity{entity}, nil, nil, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to encrypt with PGP key: %w", err)
+	}
+	if _, err := w.Write([]byte(plaintext)); err != nil {
+		return "", "", fmt.Errorf("unable to encrypt with PGP key: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", "", fmt.Errorf("unable to encrypt with PGP key: %w", err)
+	}
+
+	fingerprint := hex.EncodeToString(entity.PrimaryKey.Fingerprint)
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), fingerprint, nil
+}
+
+// readPGPPublicKey parses a PGP public key given as an ASCII-armored key, a
+// base64-encoded binary key, or a file:<path> reference to either or to a
+// binary key.
+func readPGPPublicKey(pgpKey string) (*openpgp.Entity, error) {
+	value := strings.TrimSpace(pgpKey)
+	data := []byte(value)
+
+	if path, ok := strings.CutPrefix(value, pgpKeyFilePrefix); ok {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read PGP key file: %w", err)
+		}
+		data = contents
+	}
+
+	entities, err := readPGPKeyRing(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse PGP key: %w", err)
+	}
+
+	if len(entities) != 1 {
+		return nil, fmt.Errorf("expected exactly one PGP key, got %d", len(entities))
+	}
+	return entities[0], nil
+}
+
+// readPGPKeyRing parses an ASCII-armored, base64-encoded or binary key ring.
+// Surrounding whitespace is only ignored in the text forms; binary keys are
+// parsed unchanged since their leading and trailing bytes are significant.
+func readPGPKeyRing(data []byte) (openpgp.EntityList, error) {
+	text := bytes.TrimSpace(data)
+	if bytes.HasPrefix(text, []byte("-----BEGIN")) {
+		return openpgp.ReadArmoredKeyRing(bytes.NewReader(text))
+	}
+	if raw, err := base64.StdEncoding.DecodeString(string(text)); err == nil {
+		return openpgp.ReadKeyRing(bytes.NewReader(raw))
+	}
+	return openpgp.ReadKeyRing(bytes.NewReader(data))
+}
+
+// loadPGPEncryptionKey reads a PGP public key like readPGPPublicKey and checks
+// that it can encrypt by encrypting an empty message, so that keys without a
+// usable encryption subkey, such as sign-only or expired keys, are rejected
+// before an API key is created rather than after.
+func loadPGPEncryptionKey(pgpKey string) (*openpgp.Entity, error) {
+	entity, err := readPGPPublicKey(pgpKey)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := encryptWithPGPKey(entity, ""); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
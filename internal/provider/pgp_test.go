@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+// newPGPEntity generates a PGP key with an encryption subkey.
+func newPGPEntity(t *testing.T) *openpgp.Entity {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("Terraform", "", "terraform@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entity
+}
+
+// binaryPGPPublicKey returns the public key of entity in binary form.
+func binaryPGPPublicKey(t *testing.T, entity *openpgp.Entity) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := entity.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// armoredPGPPublicKey returns the public key of entity in ASCII-armored form.
+func armoredPGPPublicKey(t *testing.T, entity *openpgp.Entity) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(binaryPGPPublicKey(t, entity)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+// decryptWithPGPEntity decrypts a base64-encoded message with entity.
+func decryptWithPGPEntity(t *testing.T, entity *openpgp.Entity, message string) string {
+	t.Helper()
+
+	raw, err := base64.StdEncoding.DecodeString(message)
+	if err != nil {
+		t.Fatalf("encrypted message is not base64: %s", err)
+	}
+	md, err := openpgp.ReadMessage(bytes.NewReader(raw), openpgp.EntityList{entity}, nil, nil)
+	if err != nil {
+		t.Fatalf("unable to decrypt message: %s", err)
+	}
+	plaintext, err := io.ReadAll(md.UnverifiedBody)
+	if err != nil {
+		t.Fatalf("unable to decrypt message: %s", err)
+	}
+	return string(plaintext)
+}
+
+func writeKeyFileForTest(t *testing.T, contents []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(path, contents, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return pgpKeyFilePrefix + path
+}
+
+func TestReadPGPPublicKey(t *testing.T) {
+	entity := newPGPEntity(t)
+	binary := binaryPGPPublicKey(t, entity)
+	armored := armoredPGPPublicKey(t, entity)
+	encoded := base64.StdEncoding.EncodeToString(binary)
+
+	tests := []struct {
+		name   string
+		pgpKey string
+	}{
+		{name: "armored", pgpKey: "\n" + armored + "\n"},
+		{name: "base64", pgpKey: encoded + "\n"},
+		{name: "armored file", pgpKey: writeKeyFileForTest(t, []byte(armored+"\n"))},
+		{name: "base64 file", pgpKey: writeKeyFileForTest(t, []byte(encoded+"\n"))},
+		{name: "binary file", pgpKey: writeKeyFileForTest(t, binary)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readPGPPublicKey(tt.pgpKey)
+			if err != nil {
+				t.Fatalf("readPGPPublicKey: %s", err)
+			}
+			if !bytes.Equal(got.PrimaryKey.Fingerprint, entity.PrimaryKey.Fingerprint) {
+				t.Errorf("readPGPPublicKey returned key %X, want %X", got.PrimaryKey.Fingerprint, entity.PrimaryKey.Fingerprint)
+			}
+		})
+	}
+}
+
+func TestReadPGPPublicKeyBinaryFileEndingInWhitespace(t *testing.T) {
+	// Signatures are random, so generate keys until one ends in a byte that
+	// trimming whitespace would strip
+	var binary []byte
+	for i := 0; i < 10000 && binary == nil; i++ {
+		candidate := binaryPGPPublicKey(t, newPGPEntity(t))
+		if last := candidate[len(candidate)-1]; strings.ContainsRune(" \t\n\v\f\r", rune(last)) {
+			binary = candidate
+		}
+	}
+	if binary == nil {
+		t.Skip("no generated key ended in whitespace")
+	}
+
+	if _, err := readPGPPublicKey(writeKeyFileForTest(t, binary)); err != nil {
+		t.Errorf("readPGPPublicKey: %s", err)
+	}
+}
+
+func TestReadPGPPublicKeyInvalid(t *testing.T) {
+	two := append(binaryPGPPublicKey(t, newPGPEntity(t)), binaryPGPPublicKey(t, newPGPEntity(t))...)
+
+	tests := []struct {
+		name    string
+		pgpKey  string
+		wantErr string
+	}{
+		{name: "garbage", pgpKey: "not a key", wantErr: "unable to parse PGP key"},
+		{name: "missing file", pgpKey: pgpKeyFilePrefix + filepath.Join(t.TempDir(), "missing"), wantErr: "unable to read PGP key file"},
+		{name: "two keys", pgpKey: base64.StdEncoding.EncodeToString(two), wantErr: "expected exactly one PGP key, got 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readPGPPublicKey(tt.pgpKey)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("readPGPPublicKey = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadPGPEncryptionKeyRejectsSignOnlyKey(t *testing.T) {
+	entity := newPGPEntity(t)
+	entity.Subkeys = nil
+
+	if _, err := loadPGPEncryptionKey(armoredPGPPublicKey(t, entity)); err == nil {
+		t.Error("loadPGPEncryptionKey accepted a key without an encryption subkey")
+	}
+}
+
+func TestEncryptWithPGPKey(t *testing.T) {
+	entity := newPGPEntity(t)
+
+	public, err := loadPGPEncryptionKey(armoredPGPPublicKey(t, entity))
+	if err != nil {
+		t.Fatalf("loadPGPEncryptionKey: %s", err)
+	}
+
+	message, fingerprint, err := encryptWithPGPKey(public, "sk-ant-api03-secret")
+	if err != nil {
+		t.Fatalf("encryptWithPGPKey: %s", err)
+	}
+	if want := hex.EncodeToString(entity.PrimaryKey.Fingerprint); fingerprint != want {
+		t.Errorf("fingerprint = %s, want %s", fingerprint, want)
+	}
+	if got := decryptWithPGPEntity(t, entity, message); got != "sk-ant-api03-secret" {
+		t.Errorf("decrypted key = %q, want %q", got, "sk-ant-api03-secret")
+	}
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// The helpers below call resource methods directly with models, the way the
+// framework does after decoding the plan and state, so that unit tests can
+// exercise CRUD logic against the fake without running Terraform.
+
+// resourceSchema returns the schema of r.
+func resourceSchema(t *testing.T, r resource.Resource) schema.Schema {
+	t.Helper()
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+	requireNoErrors(t, "Schema", resp.Diagnostics)
+	return resp.Schema
+}
+
+// nullState returns an empty state of r, as before create or after delete.
+func nullState(t *testing.T, r resource.Resource) tfsdk.State {
+	t.Helper()
+
+	s := resourceSchema(t, r)
+	return tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}
+}
+
+// stateOf returns the state of r holding model.
+func stateOf(t *testing.T, r resource.Resource, model any) tfsdk.State {
+	t.Helper()
+
+	state := nullState(t, r)
+	requireNoErrors(t, "State.Set", state.Set(context.Background(), model))
+	return state
+}
+
+// planOf returns a plan of r holding model.
+func planOf(t *testing.T, r resource.Resource, model any) tfsdk.Plan {
+	t.Helper()
+
+	state := stateOf(t, r, model)
+	return tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+}
+
+// testModifyPlan runs the plan modifier of r on plan and prior state.
+func testModifyPlan(t *testing.T, r resource.ResourceWithModifyPlan, plan tfsdk.Plan, state tfsdk.State) *resource.ModifyPlanResponse {
+	t.Helper()
+
+	config := tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{Config: config, Plan: plan, State: state}, resp)
+	return resp
+}
+
+// testCreate runs Create of r with plan.
+func testCreate(t *testing.T, r resource.Resource, plan tfsdk.Plan) *resource.CreateResponse {
+	t.Helper()
+
+	resp := &resource.CreateResponse{State: nullState(t, r)}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, resp)
+	return resp
+}
+
+// testRead runs Read of r with state.
+func testRead(t *testing.T, r resource.Resource, state tfsdk.State) *resource.ReadResponse {
+	t.Helper()
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+	return resp
+}
+
+// testUpdate runs Update of r with plan and prior state.
+func testUpdate(t *testing.T, r resource.Resource, plan tfsdk.Plan, state tfsdk.State) *resource.UpdateResponse {
+	t.Helper()
+
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: state.Schema, Raw: plan.Raw}}
+	r.Update(context.Background(), resource.UpdateRequest{Plan: plan, State: state}, resp)
+	return resp
+}
+
+// testDelete runs Delete of r with state.
+func testDelete(t *testing.T, r resource.Resource, state tfsdk.State) *resource.DeleteResponse {
+	t.Helper()
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, resp)
+	return resp
+}
+
+// getState decodes state into model.
+func getState(t *testing.T, state tfsdk.State, model any) {
+	t.Helper()
+
+	if state.Raw.IsNull() {
+		t.Fatal("state is null")
+	}
+	requireNoErrors(t, "State.Get", state.Get(context.Background(), model))
+}
+
+// requireNoErrors fails the test if diags has errors.
+func requireNoErrors(t *testing.T, operation string, diags diag.Diagnostics) {
+	t.Helper()
+
+	if diags.HasError() {
+		t.Fatalf("%s: unexpected errors: %v", operation, diags)
+	}
+}
+
+// summaries returns the summaries of the diagnostics with the given severity.
+func summaries(diags diag.Diagnostics, severity diag.Severity) []string {
+	var result []string
+	for _, d := range diags {
+		if d.Severity() == severity {
+			result = append(result, d.Summary())
+		}
+	}
+	return result
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi/fake"
+)
+
+func TestReadOnlyProvider(t *testing.T) {
+	f := fake.NewClient()
+	r := &WorkspaceResource{providerData: newProviderData(f)}
+	r.providerData.readOnly = true
+
+	data, _ := newWorkspaceState(t, f)
+
+	plan := data
+	plan.ID = types.StringUnknown()
+	create := testCreate(t, r, planOf(t, r, plan))
+
+	plan = data
+	plan.Name = types.StringValue("renamed")
+	update := testUpdate(t, r, planOf(t, r, plan), stateOf(t, r, data))
+
+	destroy := testDelete(t, r, stateOf(t, r, data))
+
+	for operation, diags := range map[string]diag.Diagnostics{"Create": create.Diagnostics, "Update": update.Diagnostics, "Delete": destroy.Diagnostics} {
+		if got := summaries(diags, diag.SeverityError); !reflect.DeepEqual(got, []string{"Provider Is Read-Only"}) {
+			t.Errorf("%s errors = %v, want Provider Is Read-Only", operation, got)
+		}
+	}
+
+	// Reads still work
+	requireNoErrors(t, "Read", testRead(t, r, stateOf(t, r, data)).Diagnostics)
+
+	workspaces, err := f.ListAllWorkspaces(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(workspaces) != 1 || workspaces[0].Name != "production" || workspaces[0].ArchivedAt != "" {
+		t.Errorf("read-only provider changed the workspaces: %+v", workspaces)
+	}
+}
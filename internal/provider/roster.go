@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Roster file formats.
+const (
+	rosterFormatJSON = "json"
+	rosterFormatCSV  = "csv"
+)
+
+// Valid organization and workspace roles of roster entries.
+var (
+	rosterOrganizationRoles = []string{"user", "developer", "admin"}
+	rosterWorkspaceRoles    = []string{"workspace_user", "workspace_developer", "workspace_admin"}
+)
+
+// rosterEntry is a single user of a roster file.
+type rosterEntry struct {
+	Email      string            `json:"email"`
+	Role       string            `json:"role"`
+	Workspaces map[string]string `json:"workspaces"`
+}
+
+// readRoster reads and validates a roster file, returning its entries keyed
+// by lowercased email. An empty format is inferred from the file extension.
+func readRoster(path, format string) (map[string]rosterEntry, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+		if format != rosterFormatJSON && format != rosterFormatCSV {
+			return nil, fmt.Errorf("unable to infer the format of %s from its extension, set format to json or csv", path)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read roster file: %w", err)
+	}
+
+	var entries []rosterEntry
+	switch format {
+	case rosterFormatJSON:
+		entries, err = parseRosterJSON(data)
+	case rosterFormatCSV:
+		entries, err = parseRosterCSV(data)
+	default:
+		err = fmt.Errorf("unsupported roster format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	roster := map[string]rosterEntry{}
+	for i, entry := range entries {
+		entry.Email = strings.TrimSpace(entry.Email)
+		if !strings.Contains(entry.Email, "@") {
+			return nil, fmt.Errorf("entry %d: invalid email %q", i+1, entry.Email)
+		}
+
+		if entry.Role == "" {
+			entry.Role = "user"
+		}
+		if !slices.Contains(rosterOrganizationRoles, entry.Role) {
+			return nil, fmt.Errorf("entry %d (%s): invalid role %q, expected one of %s", i+1, entry.Email, entry.Role, strings.Join(rosterOrganizationRoles, ", "))
+		}
+
+		if entry.Workspaces == nil {
+			entry.Workspaces = map[string]string{}
+		}
+		for workspaceID, role := range entry.Workspaces {
+			if !slices.Contains(rosterWorkspaceRoles, role) {
+				return nil, fmt.Errorf("entry %d (%s): invalid role %q in workspace %s, expected one of %s", i+1, entry.Email, role, workspaceID, strings.Join(rosterWorkspaceRoles, ", "))
+			}
+		}
+
+		key := strings.ToLower(entry.Email)
+		if _, ok := roster[key]; ok {
+			return nil, fmt.Errorf("entry %d: %s is listed more than once", i+1, entry.Email)
+		}
+		roster[key] = entry
+	}
+
+	return roster, nil
+}
+
+// parseRosterJSON parses a JSON array of roster entries.
+func parseRosterJSON(data []byte) ([]rosterEntry, error) {
+	var entries []rosterEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("unable to parse roster JSON: %w", err)
+	}
+	return entries, nil
+}
+
+// parseRosterCSV parses a CSV roster with a header row naming the email, role
+// and workspaces columns. Workspaces are given as workspace_id=role pairs
+// separated by semicolons.
+func parseRosterCSV(data []byte) ([]rosterEntry, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read roster CSV header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["email"]; !ok {
+		return nil, errors.New("roster CSV has no email column")
+	}
+
+	column := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var entries []rosterEntry
+	for row := 2; ; row++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse roster CSV: %w", err)
+		}
+
+		entry := rosterEntry{
+			Email:      column(record, "email"),
+			Role:       column(record, "role"),
+			Workspaces: map[string]string{},
+		}
+		for _, assignment := range strings.Split(column(record, "workspaces"), ";") {
+			assignment = strings.TrimSpace(assignment)
+			if assignment == "" {
+				continue
+			}
+			workspaceID, role, ok := strings.Cut(assignment, "=")
+			if !ok {
+				return nil, fmt.Errorf("row %d: expected workspaces as workspace_id=role pairs separated by semicolons, got %q", row, assignment)
+			}
+			entry.Workspaces[strings.TrimSpace(workspaceID)] = strings.TrimSpace(role)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
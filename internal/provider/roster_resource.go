@@ -0,0 +1,693 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RosterResource{}
+var _ resource.ResourceWithModifyPlan = &RosterResource{}
+
+// Statuses of roster users.
+const (
+	rosterStatusMember  = "member"
+	rosterStatusInvited = "invited"
+)
+
+func NewRosterResource() resource.Resource {
+	return &RosterResource{}
+}
+
+// RosterResource defines the resource implementation.
+type RosterResource struct {
+	providerData *AnthropicProviderData
+}
+
+// RosterResourceModel describes the resource data model.
+type RosterResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Path         types.String `tfsdk:"path"`
+	Format       types.String `tfsdk:"format"`
+	Offboard     types.Bool   `tfsdk:"offboard"`
+	Users        types.Map    `tfsdk:"users"`
+	Organization types.String `tfsdk:"organization"`
+}
+
+// RosterUserModel describes a single user managed by a roster.
+type RosterUserModel struct {
+	UserID     types.String `tfsdk:"user_id"`
+	Status     types.String `tfsdk:"status"`
+	Role       types.String `tfsdk:"role"`
+	Workspaces types.Map    `tfsdk:"workspaces"`
+}
+
+// rosterUserAttrTypes are the attribute types of the users map elements.
+var rosterUserAttrTypes = map[string]attr.Type{
+	"user_id":    types.StringType,
+	"status":     types.StringType,
+	"role":       types.StringType,
+	"workspaces": types.MapType{ElemType: types.StringType},
+}
+
+// rosterUser is the state of a single user of a roster, either as configured
+// or as it currently exists.
+type rosterUser struct {
+	Email      string
+	UserID     string
+	InviteID   string
+	Status     string
+	Role       string
+	Workspaces map[string]string
+}
+
+func (r *RosterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_roster"
+}
+
+func (r *RosterResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Synchronizes the organization with a roster file listing the email, organization role and workspace roles of every user. Users missing from the organization are invited, organization roles and workspace memberships are updated, and users missing from the roster can be offboarded.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the roster.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Description: "The path of the roster file. The file is read on every plan.",
+				Required:    true,
+			},
+			"format": schema.StringAttribute{
+				Description: "The format of the roster file (json, csv). Defaults to the file extension.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(rosterFormatJSON, rosterFormatCSV),
+				},
+			},
+			"offboard": schema.BoolAttribute{
+				Description: "Whether organization members and pending invites missing from the roster are removed from the organization. Organization admins are never offboarded. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"users": schema.MapNestedAttribute{
+				Description: "The users managed by the roster, keyed by lowercased email. Planned from the roster file and refreshed from the organization, so that the plan shows every change.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_id": schema.StringAttribute{
+							Description: "The ID of the user. Null until the user accepts their invite.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Whether the user is a member of the organization or has a pending invite (member, invited).",
+							Computed:    true,
+						},
+						"role": schema.StringAttribute{
+							Description: "The organization role of the user.",
+							Computed:    true,
+						},
+						"workspaces": schema.MapAttribute{
+							Description: "The role of the user in each workspace the roster manages, keyed by workspace ID. Always empty for invited users and organization admins.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+			"organization": schema.StringAttribute{
+				Description: "The name of an entry in the provider's organizations map whose credentials are used for this resource. Defaults to the provider's admin_key. Changing it forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RosterResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*AnthropicProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.AnthropicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *RosterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx, span := startSpan(ctx, "anthropic_roster", "plan")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	// Nothing to plan on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.providerData == nil {
+		return
+	}
+
+	var plan RosterResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Path.IsUnknown() || plan.Format.IsUnknown() {
+		plan.Users = types.MapUnknown(types.ObjectType{AttrTypes: rosterUserAttrTypes})
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	c, diags := r.providerData.clientFor(plan.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Plan the users themselves so that the plan shows every invite, role
+	// change, membership change and offboarding
+	desired, diags := r.desiredUsers(ctx, c, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, email := range sortedKeys(desired) {
+		user := desired[email]
+		for _, workspaceID := range sortedKeys(user.Workspaces) {
+			if !r.providerData.memberships.register(fmt.Sprintf("%s/%s", workspaceID, user.UserID)) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("path"),
+					"Duplicate Workspace Membership",
+					fmt.Sprintf("User %s is declared as a member of workspace %s by more than one resource. Each membership must be managed by a single anthropic_roster, anthropic_team or anthropic_workspace_member resource.", user.Email, workspaceID),
+				)
+			}
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setUsers(ctx, &plan, desired)...)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *RosterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "anthropic_roster", "create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data RosterResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.providerData.checkWritable("create roster")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to generate roster ID: %s", err))
+		return
+	}
+	data.ID = types.StringValue(id)
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_roster", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, c, &data, map[string]rosterUser{})...)
+	if data.Users.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RosterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "anthropic_roster", "read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data RosterResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := r.getUsers(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	emails := map[string]bool{}
+	for email := range current {
+		emails[email] = true
+	}
+
+	actual, err := currentRosterUsers(ctx, c, emails, data.Offboard.ValueBool(), rosterWorkspaceIDs(current))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read roster users: %s", err))
+		return
+	}
+
+	// Only the memberships managed by the roster are refreshed, so that
+	// memberships managed elsewhere never show up as changes to remove
+	resp.Diagnostics.Append(r.setUsers(ctx, &data, managedRosterUsers(actual, current))...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RosterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "anthropic_roster", "update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data RosterResourceModel
+	var state RosterResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.providerData.checkWritable("update roster")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_roster", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed, diags := r.getUsers(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, c, &data, managed)...)
+	if data.Users.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RosterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	_, span := startSpan(ctx, "anthropic_roster", "delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	// Destroying a roster only stops synchronizing the organization with it;
+	// offboarding everyone it listed is never what is wanted
+}
+
+// sync applies the planned users of data to the organization and replaces
+// them with the users that exist afterwards if anything failed. Only the
+// memberships in managed, the users saved by the previous apply, are removed.
+// Users are left unknown if they could not be determined at all.
+func (r *RosterResource) sync(ctx context.Context, c adminapi.AdminAPI, data *RosterResourceModel, managed map[string]rosterUser) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Users are unknown here if the roster path was not known while planning
+	var desired map[string]rosterUser
+	var d diag.Diagnostics
+	if data.Users.IsUnknown() {
+		desired, d = r.desiredUsers(ctx, c, *data)
+	} else {
+		desired, d = r.getUsers(ctx, *data)
+	}
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	emails := map[string]bool{}
+	for email := range desired {
+		emails[email] = true
+	}
+
+	workspaceIDs := rosterWorkspaceIDs(desired, managed)
+	actual, err := currentRosterUsers(ctx, c, emails, data.Offboard.ValueBool(), workspaceIDs)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read roster users: %s", err))
+		return diags
+	}
+
+	if !applyRoster(ctx, c, desired, actual, managed, data.Offboard.ValueBool(), &diags) {
+		// Save what actually exists so that the next plan retries the rest,
+		// keeping track of the memberships the roster manages
+		actual, err = currentRosterUsers(ctx, c, emails, data.Offboard.ValueBool(), workspaceIDs)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read roster users: %s", err))
+			return diags
+		}
+		desired = managedRosterUsers(actual, desired, managed)
+	}
+
+	diags.Append(r.setUsers(ctx, data, desired)...)
+	return diags
+}
+
+// desiredUsers reads the roster file of data and returns the users it
+// describes. Users who are not members of the organization yet are planned as
+// invited, without workspaces until they accept their invite.
+func (r *RosterResource) desiredUsers(ctx context.Context, c adminapi.AdminAPI, data RosterResourceModel) (map[string]rosterUser, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	entries, err := readRoster(data.Path.ValueString(), data.Format.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("path"), "Invalid Roster File", err.Error())
+		return nil, diags
+	}
+
+	emails := map[string]bool{}
+	for email := range entries {
+		emails[email] = true
+	}
+
+	// Only the status and user ID of each user are needed here
+	actual, err := currentRosterUsers(ctx, c, emails, false, nil)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read roster users: %s", err))
+		return nil, diags
+	}
+
+	desired := map[string]rosterUser{}
+	for email, entry := range entries {
+		user := rosterUser{
+			Email:      entry.Email,
+			Status:     rosterStatusInvited,
+			Role:       entry.Role,
+			Workspaces: map[string]string{},
+		}
+		if existing, ok := actual[email]; ok && existing.Status == rosterStatusMember {
+			user.UserID = existing.UserID
+			user.Status = rosterStatusMember
+			// Organization admins are implicitly admins of every workspace
+			if entry.Role != "admin" {
+				user.Workspaces = entry.Workspaces
+			}
+		}
+		desired[email] = user
+	}
+
+	return desired, diags
+}
+
+// currentRosterUsers returns the current state of the organization members
+// and pending invites with the given lowercased emails, or of all of them
+// except organization admins if all is set. Only memberships of the given
+// workspaces are listed.
+func currentRosterUsers(ctx context.Context, c adminapi.AdminAPI, emails map[string]bool, all bool, workspaceIDs map[string]bool) (map[string]rosterUser, error) {
+	members, err := c.ListAllOrganizationMembers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list organization members: %w", err)
+	}
+
+	invites, err := c.ListAllInvites(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list invites: %w", err)
+	}
+
+	users := map[string]rosterUser{}
+	byID := map[string]string{}
+	for _, member := range members {
+		email := strings.ToLower(member.Email)
+		if !emails[email] && (!all || member.Role == "admin") {
+			continue
+		}
+		users[email] = rosterUser{
+			Email:      member.Email,
+			UserID:     member.ID,
+			Status:     rosterStatusMember,
+			Role:       member.Role,
+			Workspaces: map[string]string{},
+		}
+		if member.Role != "admin" {
+			byID[member.ID] = email
+		}
+	}
+
+	for _, invite := range invites {
+		email := strings.ToLower(invite.Email)
+		if invite.Status != "pending" || (!emails[email] && (!all || invite.Role == "admin")) {
+			continue
+		}
+		if _, ok := users[email]; ok {
+			continue
+		}
+		users[email] = rosterUser{
+			Email:      invite.Email,
+			InviteID:   invite.ID,
+			Status:     rosterStatusInvited,
+			Role:       invite.Role,
+			Workspaces: map[string]string{},
+		}
+	}
+
+	if len(byID) == 0 {
+		return users, nil
+	}
+
+	for _, workspaceID := range sortedKeys(workspaceIDs) {
+		workspaceMembers, err := c.ListAllWorkspaceMembers(ctx, workspaceID)
+		// A workspace deleted out of band has no members left to manage
+		if adminapi.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to list members of workspace %s: %w", workspaceID, err)
+		}
+		for _, workspaceMember := range workspaceMembers {
+			if email, ok := byID[workspaceMember.UserID]; ok {
+				users[email].Workspaces[workspaceID] = workspaceMember.WorkspaceRole
+			}
+		}
+	}
+
+	return users, nil
+}
+
+// rosterWorkspaceIDs returns the IDs of the workspaces the given users are
+// members of.
+func rosterWorkspaceIDs(users ...map[string]rosterUser) map[string]bool {
+	workspaceIDs := map[string]bool{}
+	for _, byEmail := range users {
+		for _, user := range byEmail {
+			for workspaceID := range user.Workspaces {
+				workspaceIDs[workspaceID] = true
+			}
+		}
+	}
+	return workspaceIDs
+}
+
+// managedRosterUsers returns actual with the workspaces of each user limited
+// to those the same user has in any of managed.
+func managedRosterUsers(actual map[string]rosterUser, managed ...map[string]rosterUser) map[string]rosterUser {
+	users := map[string]rosterUser{}
+	for email, user := range actual {
+		workspaces := map[string]string{}
+		for workspaceID, role := range user.Workspaces {
+			for _, byEmail := range managed {
+				if _, ok := byEmail[email].Workspaces[workspaceID]; ok {
+					workspaces[workspaceID] = role
+					break
+				}
+			}
+		}
+		user.Workspaces = workspaces
+		users[email] = user
+	}
+	return users
+}
+
+// applyRoster makes actual match desired by sending invites, updating
+// organization roles and workspace memberships and, if offboard is set,
+// removing users missing from desired. Only the memberships in managed are
+// removed, so that memberships created outside of the roster are left alone.
+// Every change is attempted even if others fail, and each failure is reported
+// as its own error. It reports whether every change succeeded.
+func applyRoster(ctx context.Context, c adminapi.AdminAPI, desired, actual, managed map[string]rosterUser, offboard bool, diags *diag.Diagnostics) bool {
+	var attempted, failed int
+	change := func(description string, err error) {
+		attempted++
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to %s: %s", description, err))
+			failed++
+		}
+	}
+
+	emails := map[string]bool{}
+	for email := range desired {
+		emails[email] = true
+	}
+	for email := range actual {
+		emails[email] = true
+	}
+
+	for _, email := range sortedKeys(emails) {
+		want, wanted := desired[email]
+		have, exists := actual[email]
+
+		switch {
+		case wanted && !exists:
+			_, err := c.CreateInvite(ctx, &adminapi.CreateInviteRequest{Email: want.Email, Role: want.Role})
+			change(fmt.Sprintf("invite %s as %s", want.Email, want.Role), err)
+
+		case wanted && have.Status == rosterStatusInvited:
+			if want.Role == have.Role {
+				continue
+			}
+			// Invites cannot be updated, so they are sent again with the new role
+			err := c.DeleteInvite(ctx, have.InviteID)
+			if err == nil {
+				_, err = c.CreateInvite(ctx, &adminapi.CreateInviteRequest{Email: want.Email, Role: want.Role})
+			}
+			change(fmt.Sprintf("invite %s again as %s", want.Email, want.Role), err)
+
+		case wanted:
+			if want.Role != have.Role {
+				_, err := c.UpdateOrganizationMember(ctx, have.UserID, &adminapi.UpdateOrganizationMemberRequest{Role: want.Role})
+				change(fmt.Sprintf("change the role of %s to %s", want.Email, want.Role), err)
+			}
+
+			// Users who accepted their invite since the plan get their
+			// workspaces on the next apply
+			if want.Status != rosterStatusMember {
+				continue
+			}
+
+			for _, workspaceID := range sortedKeys(have.Workspaces) {
+				_, ok := want.Workspaces[workspaceID]
+				_, owned := managed[email].Workspaces[workspaceID]
+				if !ok && owned {
+					err := c.RemoveWorkspaceMember(ctx, workspaceID, have.UserID)
+					change(fmt.Sprintf("remove %s from workspace %s", want.Email, workspaceID), err)
+				}
+			}
+			for _, workspaceID := range sortedKeys(want.Workspaces) {
+				role := want.Workspaces[workspaceID]
+				current, ok := have.Workspaces[workspaceID]
+				switch {
+				case !ok:
+					_, err := c.AddWorkspaceMember(ctx, workspaceID, &adminapi.AddWorkspaceMemberRequest{UserID: have.UserID, WorkspaceRole: role})
+					change(fmt.Sprintf("add %s to workspace %s as %s", want.Email, workspaceID, role), err)
+				case current != role:
+					_, err := c.UpdateWorkspaceMember(ctx, workspaceID, have.UserID, &adminapi.UpdateWorkspaceMemberRequest{WorkspaceRole: role})
+					change(fmt.Sprintf("change the role of %s in workspace %s to %s", want.Email, workspaceID, role), err)
+				}
+			}
+
+		case offboard && have.Status == rosterStatusInvited:
+			change(fmt.Sprintf("delete the invite of %s", have.Email), c.DeleteInvite(ctx, have.InviteID))
+
+		case offboard:
+			change(fmt.Sprintf("offboard %s", have.Email), c.RemoveOrganizationMember(ctx, have.UserID))
+		}
+	}
+
+	if failed > 0 {
+		diags.AddError(
+			"Roster Partially Applied",
+			fmt.Sprintf("%d of %d roster changes failed, see the errors above. The changes that were applied have been saved and the failed ones will be retried on the next apply.", failed, attempted),
+		)
+	}
+
+	return failed == 0
+}
+
+// getUsers decodes the users attribute, treating null and unknown as empty.
+func (r *RosterResource) getUsers(ctx context.Context, data RosterResourceModel) (map[string]rosterUser, diag.Diagnostics) {
+	users := map[string]rosterUser{}
+	if data.Users.IsNull() || data.Users.IsUnknown() {
+		return users, nil
+	}
+
+	models := map[string]RosterUserModel{}
+	diags := data.Users.ElementsAs(ctx, &models, false)
+	for email, model := range models {
+		user := rosterUser{
+			Email:      email,
+			UserID:     model.UserID.ValueString(),
+			Status:     model.Status.ValueString(),
+			Role:       model.Role.ValueString(),
+			Workspaces: map[string]string{},
+		}
+		diags.Append(model.Workspaces.ElementsAs(ctx, &user.Workspaces, false)...)
+		users[email] = user
+	}
+	return users, diags
+}
+
+// setUsers encodes users into the users attribute of data.
+func (r *RosterResource) setUsers(ctx context.Context, data *RosterResourceModel, users map[string]rosterUser) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	models := map[string]RosterUserModel{}
+	for email, user := range users {
+		workspaces, d := types.MapValueFrom(ctx, types.StringType, user.Workspaces)
+		diags.Append(d...)
+
+		model := RosterUserModel{
+			UserID:     types.StringNull(),
+			Status:     types.StringValue(user.Status),
+			Role:       types.StringValue(user.Role),
+			Workspaces: workspaces,
+		}
+		if user.UserID != "" {
+			model.UserID = types.StringValue(user.UserID)
+		}
+		models[email] = model
+	}
+
+	value, d := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: rosterUserAttrTypes}, models)
+	diags.Append(d...)
+	data.Users = value
+	return diags
+}
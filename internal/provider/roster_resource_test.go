@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi/fake"
+)
+
+// rosterFixture is an organization with two workspaces, a developer in both,
+// a user in neither, an admin and a pending invite.
+type rosterFixture struct {
+	f          *fake.Client
+	production string
+	staging    string
+	inviteID   string
+}
+
+func newRosterFixture(t *testing.T) rosterFixture {
+	t.Helper()
+	ctx := context.Background()
+
+	f := fake.NewClient()
+	production, err := f.CreateWorkspace(ctx, &adminapi.CreateWorkspaceRequest{Name: "production"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	staging, err := f.CreateWorkspace(ctx, &adminapi.CreateWorkspaceRequest{Name: "staging"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.AddOrganizationMember(adminapi.OrganizationMember{ID: "user_ada", Email: "Ada@example.com", Role: "developer"})
+	f.AddOrganizationMember(adminapi.OrganizationMember{ID: "user_bob", Email: "bob@example.com", Role: "user"})
+	f.AddOrganizationMember(adminapi.OrganizationMember{ID: "user_carol", Email: "carol@example.com", Role: "admin"})
+	for _, workspaceID := range []string{production.ID, staging.ID} {
+		if _, err := f.AddWorkspaceMember(ctx, workspaceID, &adminapi.AddWorkspaceMemberRequest{UserID: "user_ada", WorkspaceRole: "workspace_user"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	invite, err := f.CreateInvite(ctx, &adminapi.CreateInviteRequest{Email: "dan@example.com", Role: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rosterFixture{f: f, production: production.ID, staging: staging.ID, inviteID: invite.ID}
+}
+
+// desired returns the roster users the tests apply: ada becomes a user and
+// developer in production only, dan's invite changes role and eve is invited.
+func (x rosterFixture) desired() map[string]rosterUser {
+	return map[string]rosterUser{
+		"ada@example.com": {
+			Email:      "Ada@example.com",
+			UserID:     "user_ada",
+			Status:     rosterStatusMember,
+			Role:       "user",
+			Workspaces: map[string]string{x.production: "workspace_developer"},
+		},
+		"dan@example.com": {Email: "dan@example.com", Status: rosterStatusInvited, Role: "developer", Workspaces: map[string]string{}},
+		"eve@example.com": {Email: "eve@example.com", Status: rosterStatusInvited, Role: "user", Workspaces: map[string]string{}},
+	}
+}
+
+// managed returns the roster users saved by a previous apply that made ada a
+// member of both workspaces.
+func (x rosterFixture) managed() map[string]rosterUser {
+	return map[string]rosterUser{
+		"ada@example.com": {
+			Email:      "Ada@example.com",
+			UserID:     "user_ada",
+			Status:     rosterStatusMember,
+			Role:       "developer",
+			Workspaces: map[string]string{x.production: "workspace_user", x.staging: "workspace_user"},
+		},
+	}
+}
+
+// apply applies the desired users over managed the way sync does.
+func (x rosterFixture) apply(t *testing.T, offboard bool, managed map[string]rosterUser) (bool, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+
+	desired := x.desired()
+	emails := map[string]bool{}
+	for email := range desired {
+		emails[email] = true
+	}
+	actual, err := currentRosterUsers(ctx, x.f, emails, offboard, rosterWorkspaceIDs(desired, managed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var diags diag.Diagnostics
+	ok := applyRoster(ctx, x.f, desired, actual, managed, offboard, &diags)
+	return ok, diags
+}
+
+func TestCurrentRosterUsers(t *testing.T) {
+	x := newRosterFixture(t)
+
+	workspaceIDs := map[string]bool{x.production: true, x.staging: true}
+	users, err := currentRosterUsers(context.Background(), x.f, map[string]bool{"ada@example.com": true}, true, workspaceIDs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]rosterUser{
+		"ada@example.com": {
+			Email:      "Ada@example.com",
+			UserID:     "user_ada",
+			Status:     rosterStatusMember,
+			Role:       "developer",
+			Workspaces: map[string]string{x.production: "workspace_user", x.staging: "workspace_user"},
+		},
+		"bob@example.com": {Email: "bob@example.com", UserID: "user_bob", Status: rosterStatusMember, Role: "user", Workspaces: map[string]string{}},
+		"dan@example.com": {Email: "dan@example.com", InviteID: x.inviteID, Status: rosterStatusInvited, Role: "user", Workspaces: map[string]string{}},
+	}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("currentRosterUsers() = %#v, want %#v", users, want)
+	}
+
+	// Workspaces not asked for are not listed
+	x.f.Errors["ListAllWorkspaceMembers"] = errors.New("unexpected listing")
+	users, err = currentRosterUsers(context.Background(), x.f, map[string]bool{"ada@example.com": true}, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := users["ada@example.com"].Workspaces; len(got) != 0 {
+		t.Errorf("currentRosterUsers() without workspaces listed memberships %v", got)
+	}
+}
+
+func TestApplyRoster(t *testing.T) {
+	x := newRosterFixture(t)
+	ctx := context.Background()
+
+	ok, diags := x.apply(t, true, x.managed())
+	if !ok || diags.HasError() {
+		t.Fatalf("applyRoster() = %t, %v", ok, diags)
+	}
+
+	ada, err := x.f.GetOrganizationMember(ctx, "user_ada")
+	if err != nil || ada.Role != "user" {
+		t.Errorf("ada = %+v, %v, want role user", ada, err)
+	}
+	members, _ := x.f.ListAllWorkspaceMembers(ctx, x.production)
+	if len(members) != 1 || members[0].WorkspaceRole != "workspace_developer" {
+		t.Errorf("production members = %+v, want ada as workspace_developer", members)
+	}
+	if _, err := x.f.GetWorkspaceMember(ctx, x.staging, "user_ada"); !adminapi.IsNotFound(err) {
+		t.Errorf("ada is still a member of staging: %v", err)
+	}
+
+	// Offboarding removes bob but never touches the admin
+	if _, err := x.f.GetOrganizationMember(ctx, "user_bob"); !adminapi.IsNotFound(err) {
+		t.Errorf("bob was not offboarded: %v", err)
+	}
+	if _, err := x.f.GetOrganizationMember(ctx, "user_carol"); err != nil {
+		t.Errorf("carol was offboarded: %v", err)
+	}
+
+	invites, _ := x.f.ListAllInvites(ctx)
+	roles := map[string]string{}
+	for _, invite := range invites {
+		roles[invite.Email] = invite.Role
+	}
+	if want := map[string]string{"dan@example.com": "developer", "eve@example.com": "user"}; !reflect.DeepEqual(roles, want) {
+		t.Errorf("invites = %v, want %v", roles, want)
+	}
+
+	// Applying again changes nothing
+	ok, diags = x.apply(t, true, x.desired())
+	if !ok || diags.HasError() {
+		t.Fatalf("second applyRoster() = %t, %v", ok, diags)
+	}
+	if invites, _ := x.f.ListAllInvites(ctx); len(invites) != 2 {
+		t.Errorf("second apply changed invites: %+v", invites)
+	}
+}
+
+func TestApplyRosterWithoutOffboard(t *testing.T) {
+	x := newRosterFixture(t)
+
+	if ok, diags := x.apply(t, false, x.managed()); !ok || diags.HasError() {
+		t.Fatalf("applyRoster() = %t, %v", ok, diags)
+	}
+	if _, err := x.f.GetOrganizationMember(context.Background(), "user_bob"); err != nil {
+		t.Errorf("bob was offboarded without offboard: %v", err)
+	}
+}
+
+func TestApplyRosterPartialFailure(t *testing.T) {
+	x := newRosterFixture(t)
+	ctx := context.Background()
+	x.f.Errors["UpdateOrganizationMember"] = errors.New("connection reset")
+
+	ok, diags := x.apply(t, true, x.managed())
+	if ok {
+		t.Fatal("applyRoster() succeeded despite a failed change")
+	}
+	if diags.ErrorsCount() != 2 || diags.Errors()[1].Summary() != "Roster Partially Applied" {
+		t.Errorf("diagnostics = %v, want the failed change and a summary", diags)
+	}
+
+	// The other changes are still applied
+	if _, err := x.f.GetWorkspaceMember(ctx, x.staging, "user_ada"); !adminapi.IsNotFound(err) {
+		t.Errorf("ada is still a member of staging: %v", err)
+	}
+	if _, err := x.f.GetOrganizationMember(ctx, "user_bob"); !adminapi.IsNotFound(err) {
+		t.Errorf("bob was not offboarded: %v", err)
+	}
+}
+
+func TestApplyRosterUnmanagedMembership(t *testing.T) {
+	x := newRosterFixture(t)
+	ctx := context.Background()
+
+	// The staging membership of ada was never managed by the roster, such as
+	// one declared by anthropic_workspace_member, so it is left alone
+	if ok, diags := x.apply(t, false, map[string]rosterUser{}); !ok || diags.HasError() {
+		t.Fatalf("applyRoster() = %t, %v", ok, diags)
+	}
+
+	member, err := x.f.GetWorkspaceMember(ctx, x.staging, "user_ada")
+	if err != nil || member.WorkspaceRole != "workspace_user" {
+		t.Errorf("staging membership of ada = %+v, %v, want it unchanged", member, err)
+	}
+	member, err = x.f.GetWorkspaceMember(ctx, x.production, "user_ada")
+	if err != nil || member.WorkspaceRole != "workspace_developer" {
+		t.Errorf("production membership of ada = %+v, %v, want workspace_developer", member, err)
+	}
+}
+
+func TestManagedRosterUsers(t *testing.T) {
+	actual := map[string]rosterUser{
+		"ada@example.com": {Email: "ada@example.com", Workspaces: map[string]string{"wrkspc_a": "workspace_user", "wrkspc_b": "workspace_user", "wrkspc_c": "workspace_user"}},
+		"bob@example.com": {Email: "bob@example.com", Workspaces: map[string]string{"wrkspc_a": "workspace_admin"}},
+	}
+	desired := map[string]rosterUser{
+		"ada@example.com": {Workspaces: map[string]string{"wrkspc_a": "workspace_developer"}},
+	}
+	managed := map[string]rosterUser{
+		"ada@example.com": {Workspaces: map[string]string{"wrkspc_b": "workspace_user"}},
+	}
+
+	got := managedRosterUsers(actual, desired, managed)
+	want := map[string]rosterUser{
+		"ada@example.com": {Email: "ada@example.com", Workspaces: map[string]string{"wrkspc_a": "workspace_user", "wrkspc_b": "workspace_user"}},
+		"bob@example.com": {Email: "bob@example.com", Workspaces: map[string]string{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("managedRosterUsers() = %v, want %v", got, want)
+	}
+}
+
+func TestRosterRead(t *testing.T) {
+	x := newRosterFixture(t)
+	r := &RosterResource{providerData: newProviderData(x.f)}
+
+	managed := map[string]rosterUser{
+		"ada@example.com": {UserID: "user_ada", Status: rosterStatusMember, Role: "developer", Workspaces: map[string]string{x.production: "workspace_developer"}},
+	}
+	data := RosterResourceModel{
+		ID:           types.StringValue("roster"),
+		Path:         types.StringValue("roster.json"),
+		Format:       types.StringNull(),
+		Offboard:     types.BoolValue(false),
+		Organization: types.StringNull(),
+	}
+	requireNoErrors(t, "setUsers", r.setUsers(context.Background(), &data, managed))
+
+	resp := testRead(t, r, stateOf(t, r, data))
+	requireNoErrors(t, "Read", resp.Diagnostics)
+
+	// The role change shows up, the unmanaged staging membership does not
+	var refreshed RosterResourceModel
+	getState(t, resp.State, &refreshed)
+	users, diags := r.getUsers(context.Background(), refreshed)
+	requireNoErrors(t, "getUsers", diags)
+	if got, want := users["ada@example.com"].Workspaces, map[string]string{x.production: "workspace_user"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("workspaces of ada after read = %v, want %v", got, want)
+	}
+}
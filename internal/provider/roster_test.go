@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeRoster writes content to a file named name in a temporary directory
+// and returns its path.
+func writeRoster(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadRoster(t *testing.T) {
+	jsonRoster := `[
+		{"email": "Ada@Example.com", "role": "developer", "workspaces": {"wrkspc_1": "workspace_developer"}},
+		{"email": "grace@example.com"}
+	]`
+	csvRoster := "email,role,workspaces\n" +
+		"Ada@Example.com,developer,wrkspc_1=workspace_developer\n" +
+		"grace@example.com,,\n"
+	want := map[string]rosterEntry{
+		"ada@example.com": {
+			Email:      "Ada@Example.com",
+			Role:       "developer",
+			Workspaces: map[string]string{"wrkspc_1": "workspace_developer"},
+		},
+		"grace@example.com": {
+			Email:      "grace@example.com",
+			Role:       "user",
+			Workspaces: map[string]string{},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		format  string
+		want    map[string]rosterEntry
+		wantErr string
+	}{
+		{name: "json", file: "roster.json", content: jsonRoster, want: want},
+		{name: "csv", file: "roster.csv", content: csvRoster, want: want},
+		{name: "explicit format", file: "roster.txt", content: csvRoster, format: rosterFormatCSV, want: want},
+		{name: "unknown extension", file: "roster.txt", content: csvRoster, wantErr: "unable to infer the format"},
+		{name: "invalid email", file: "roster.json", content: `[{"email": "ada"}]`, wantErr: `entry 1: invalid email "ada"`},
+		{name: "invalid role", file: "roster.json", content: `[{"email": "ada@example.com", "role": "owner"}]`, wantErr: `invalid role "owner"`},
+		{
+			name:    "invalid workspace role",
+			file:    "roster.json",
+			content: `[{"email": "ada@example.com", "workspaces": {"wrkspc_1": "owner"}}]`,
+			wantErr: `invalid role "owner" in workspace wrkspc_1`,
+		},
+		{
+			name:    "duplicate email",
+			file:    "roster.csv",
+			content: "email\nada@example.com\nADA@example.com\n",
+			wantErr: "entry 2: ADA@example.com is listed more than once",
+		},
+		{name: "malformed json", file: "roster.json", content: `{"email": "ada@example.com"}`, wantErr: "unable to parse roster JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readRoster(writeRoster(t, tt.file, tt.content), tt.format)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readRoster() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readRoster() error = %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readRoster() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadRosterMissingFile(t *testing.T) {
+	_, err := readRoster(filepath.Join(t.TempDir(), "missing.json"), "")
+	if err == nil || !strings.Contains(err.Error(), "unable to read roster file") {
+		t.Fatalf("readRoster() error = %v, want a read error", err)
+	}
+}
+
+func TestParseRosterCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []rosterEntry
+		wantErr string
+	}{
+		{
+			name:    "reordered columns and spacing",
+			content: "Workspaces, EMAIL ,role\n wrkspc_1 = workspace_admin ; wrkspc_2=workspace_user; ,ada@example.com, admin\n",
+			want: []rosterEntry{{
+				Email:      "ada@example.com",
+				Role:       "admin",
+				Workspaces: map[string]string{"wrkspc_1": "workspace_admin", "wrkspc_2": "workspace_user"},
+			}},
+		},
+		{
+			name:    "email only",
+			content: "email\nada@example.com\n",
+			want:    []rosterEntry{{Email: "ada@example.com", Workspaces: map[string]string{}}},
+		},
+		{
+			name:    "quoted fields",
+			content: "email,workspaces\n\"ada@example.com\",\"wrkspc_1=workspace_user;wrkspc_2=workspace_user\"\n",
+			want: []rosterEntry{{
+				Email:      "ada@example.com",
+				Workspaces: map[string]string{"wrkspc_1": "workspace_user", "wrkspc_2": "workspace_user"},
+			}},
+		},
+		{name: "empty", content: "", wantErr: "unable to read roster CSV header"},
+		{name: "no email column", content: "user,role\nada,user\n", wantErr: "no email column"},
+		{name: "bad workspace pair", content: "email,workspaces\nada@example.com,wrkspc_1\n", wantErr: "row 2: expected workspaces as workspace_id=role pairs"},
+		{name: "ragged row", content: "email,role\nada@example.com\n", wantErr: "unable to parse roster CSV"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRosterCSV([]byte(tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseRosterCSV() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRosterCSV() error = %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRosterCSV() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,635 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TeamResource{}
+var _ resource.ResourceWithModifyPlan = &TeamResource{}
+
+func NewTeamResource() resource.Resource {
+	return &TeamResource{}
+}
+
+// TeamResource defines the resource implementation.
+type TeamResource struct {
+	providerData *AnthropicProviderData
+}
+
+// TeamResourceModel describes the resource data model.
+type TeamResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Members            types.Set    `tfsdk:"members"`
+	Workspaces         types.Map    `tfsdk:"workspaces"`
+	Memberships        types.Map    `tfsdk:"memberships"`
+	Organization       types.String `tfsdk:"organization"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	AdoptExisting      types.Bool   `tfsdk:"adopt_existing"`
+}
+
+// TeamMembershipModel describes a single workspace membership managed by a team.
+type TeamMembershipModel struct {
+	WorkspaceID   types.String `tfsdk:"workspace_id"`
+	UserID        types.String `tfsdk:"user_id"`
+	Member        types.String `tfsdk:"member"`
+	WorkspaceRole types.String `tfsdk:"workspace_role"`
+}
+
+// teamMembershipAttrTypes are the attribute types of the memberships map elements.
+var teamMembershipAttrTypes = map[string]attr.Type{
+	"workspace_id":   types.StringType,
+	"user_id":        types.StringType,
+	"member":         types.StringType,
+	"workspace_role": types.StringType,
+}
+
+func (r *TeamResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team"
+}
+
+func (r *TeamResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a team of users with roles across several Anthropic workspaces. Every member of the team is given the configured role in every configured workspace, and memberships are added, updated and removed as the team changes.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the team.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"members": schema.SetAttribute{
+				Description: "The members of the team, given by email address or user ID. Every member must already be a member of the organization.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"workspaces": schema.MapAttribute{
+				Description: "The role of the team in each workspace, keyed by workspace ID. Valid roles: workspace_user, workspace_admin, workspace_developer.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.ValueStringsAre(stringvalidator.OneOf("workspace_user", "workspace_admin", "workspace_developer")),
+				},
+			},
+			"memberships": schema.MapNestedAttribute{
+				Description: "The workspace memberships managed by the team, keyed by workspace_id/user_id.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"workspace_id": schema.StringAttribute{
+							Description: "The ID of the workspace.",
+							Computed:    true,
+						},
+						"user_id": schema.StringAttribute{
+							Description: "The ID of the user.",
+							Computed:    true,
+						},
+						"member": schema.StringAttribute{
+							Description: "The entry of members the membership belongs to.",
+							Computed:    true,
+						},
+						"workspace_role": schema.StringAttribute{
+							Description: "The role of the user in the workspace.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether destroying or replacing the team is prevented. Must be set to false in a separate apply before the team can be destroyed. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Whether to take over memberships that already exist when the team adds them, such as those created by SSO auto-provisioning, instead of failing. The role of an adopted membership is updated to the role of the team, and the membership is removed when the team no longer manages it. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"organization": schema.StringAttribute{
+				Description: "The name of an entry in the provider's organizations map whose credentials are used for this resource. Defaults to the provider's admin_key. Changing it forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *TeamResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*AnthropicProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.AnthropicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *TeamResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx, span := startSpan(ctx, "anthropic_team", "plan")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	// Nothing to plan on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.providerData == nil {
+		return
+	}
+
+	var plan TeamResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !teamConfigKnown(plan) {
+		plan.Memberships = types.MapUnknown(types.ObjectType{AttrTypes: teamMembershipAttrTypes})
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	c, diags := r.providerData.clientFor(plan.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Plan the memberships themselves so that members removed out of band
+	// and changed roles show up as differences
+	desired, admins, diags := r.desiredMemberships(ctx, c, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, key := range sortedKeys(desired) {
+		if !r.providerData.memberships.register(key) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("members"),
+				"Duplicate Workspace Membership",
+				fmt.Sprintf("User %s is declared as a member of workspace %s by more than one resource. Each membership must be managed by a single anthropic_team, anthropic_workspace_member or anthropic_roster resource.", desired[key].UserID.ValueString(), desired[key].WorkspaceID.ValueString()),
+			)
+			return
+		}
+
+		membership := desired[key]
+		if admins[membership.UserID.ValueString()] && membership.WorkspaceRole.ValueString() != "workspace_admin" {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("workspaces"),
+				"Workspace Role Overridden by Organization Role",
+				fmt.Sprintf("Member %s is an organization admin and is always a workspace_admin of every workspace. The API will report workspace_admin instead of %s in workspace %s, which will show up as a difference on every plan.", membership.Member.ValueString(), membership.WorkspaceRole.ValueString(), membership.WorkspaceID.ValueString()),
+			)
+		}
+	}
+
+	resp.Diagnostics.Append(r.setMemberships(ctx, &plan, desired)...)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *TeamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "anthropic_team", "create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data TeamResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.providerData.checkWritable("create team")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to generate team ID: %s", err))
+		return
+	}
+	data.ID = types.StringValue(id)
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_team", data.ID.ValueString())
+
+	desired, diags := r.plannedMemberships(ctx, c, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	memberships, failures := r.reconcile(ctx, c, desired, map[string]TeamMembershipModel{}, data.AdoptExisting.ValueBool())
+
+	// Nothing was applied, so there is nothing to save
+	if len(memberships) == 0 && len(failures) > 0 {
+		for _, failure := range failures {
+			resp.Diagnostics.AddError("Client Error", failure)
+		}
+		return
+	}
+
+	// Failures are reported as warnings once some memberships exist: an error
+	// would taint the team and the next apply would remove and re-add every
+	// membership added here. The missing memberships are added by the next
+	// apply instead.
+	for _, failure := range failures {
+		resp.Diagnostics.AddWarning(
+			"Team Membership Not Applied",
+			fmt.Sprintf("%s. The other memberships of the team were saved, and the next apply will retry this one.", failure),
+		)
+	}
+
+	resp.Diagnostics.Append(r.setMemberships(ctx, &data, memberships)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "anthropic_team", "read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data TeamResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := r.getMemberships(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	memberships := map[string]TeamMembershipModel{}
+	for key, membership := range current {
+		member, err := c.GetWorkspaceMember(ctx, membership.WorkspaceID.ValueString(), membership.UserID.ValueString())
+		// Memberships removed out of band are dropped so that they get re-added
+		if adminapi.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workspace member %s: %s", key, err))
+			return
+		}
+
+		membership.WorkspaceRole = types.StringValue(member.WorkspaceRole)
+		memberships[key] = membership
+	}
+
+	resp.Diagnostics.Append(r.setMemberships(ctx, &data, memberships)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "anthropic_team", "update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data TeamResourceModel
+	var state TeamResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.providerData.checkWritable("update team")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_team", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := r.plannedMemberships(ctx, c, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := r.getMemberships(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	memberships, failures := r.reconcile(ctx, c, desired, current, data.AdoptExisting.ValueBool())
+	for _, failure := range failures {
+		resp.Diagnostics.AddError("Client Error", failure)
+	}
+	if len(failures) > 0 {
+		resp.Diagnostics.AddError(
+			"Team Partially Applied",
+			fmt.Sprintf("%d membership changes failed, see the errors above. The memberships that were applied have been saved and the failed ones will be retried on the next apply.", len(failures)),
+		)
+	}
+
+	// Save whatever was applied so that a partial failure does not orphan memberships
+	resp.Diagnostics.Append(r.setMemberships(ctx, &data, memberships)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "anthropic_team", "delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data TeamResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.providerData.checkWritable("destroy team")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_team", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Deletion Protection Enabled",
+			fmt.Sprintf("Team %s has deletion_protection enabled. Set deletion_protection to false and apply that change before destroying or replacing it.", data.ID.ValueString()),
+		)
+		return
+	}
+
+	current, diags := r.getMemberships(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remaining, failures := r.reconcile(ctx, c, map[string]TeamMembershipModel{}, current, false)
+	for _, failure := range failures {
+		resp.Diagnostics.AddError("Client Error", failure)
+	}
+
+	// Keep the memberships that could not be removed so the next destroy retries them
+	if len(remaining) > 0 {
+		resp.Diagnostics.Append(r.setMemberships(ctx, &data, remaining)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	}
+}
+
+// reconcile adds, updates and removes workspace memberships until current
+// matches desired. Memberships that already exist when they are added are
+// only taken over if adoptExisting is set. Every membership is attempted even
+// if others fail. It returns the memberships that exist afterwards as far as
+// is known, and a message for each membership change that failed.
+func (r *TeamResource) reconcile(ctx context.Context, c adminapi.AdminAPI, desired, current map[string]TeamMembershipModel, adoptExisting bool) (map[string]TeamMembershipModel, []string) {
+	result := map[string]TeamMembershipModel{}
+	var failures []string
+
+	for _, key := range sortedKeys(current) {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+
+		membership := current[key]
+		err := c.RemoveWorkspaceMember(ctx, membership.WorkspaceID.ValueString(), membership.UserID.ValueString())
+		if err != nil && !adminapi.IsNotFound(err) {
+			failures = append(failures, fmt.Sprintf("Unable to remove %s from workspace %s: %s", membership.Member.ValueString(), membership.WorkspaceID.ValueString(), err))
+			result[key] = membership
+		}
+	}
+
+	for _, key := range sortedKeys(desired) {
+		membership := desired[key]
+		existing, ok := current[key]
+
+		switch {
+		case !ok:
+			_, err := c.AddWorkspaceMember(ctx, membership.WorkspaceID.ValueString(), &adminapi.AddWorkspaceMemberRequest{
+				UserID:        membership.UserID.ValueString(),
+				WorkspaceRole: membership.WorkspaceRole.ValueString(),
+			})
+			if err != nil && adoptExisting {
+				err = r.adoptMembership(ctx, c, membership, err)
+			}
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("Unable to add %s to workspace %s: %s", membership.Member.ValueString(), membership.WorkspaceID.ValueString(), err))
+				continue
+			}
+		case !existing.WorkspaceRole.Equal(membership.WorkspaceRole):
+			_, err := c.UpdateWorkspaceMember(ctx, membership.WorkspaceID.ValueString(), membership.UserID.ValueString(), &adminapi.UpdateWorkspaceMemberRequest{
+				WorkspaceRole: membership.WorkspaceRole.ValueString(),
+			})
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("Unable to update the role of %s in workspace %s: %s", membership.Member.ValueString(), membership.WorkspaceID.ValueString(), err))
+				result[key] = existing
+				continue
+			}
+		}
+
+		// The planned role is kept even for organization admins, whom the
+		// API always reports as workspace_admin, to match the plan
+		result[key] = membership
+	}
+
+	return result, failures
+}
+
+// adoptMembership takes over a membership that could not be added because the
+// user is already a member of the workspace, updating its role if needed. It
+// returns addErr if the user is not a member.
+func (r *TeamResource) adoptMembership(ctx context.Context, c adminapi.AdminAPI, membership TeamMembershipModel, addErr error) error {
+	member, err := c.GetWorkspaceMember(ctx, membership.WorkspaceID.ValueString(), membership.UserID.ValueString())
+	if err != nil {
+		return addErr
+	}
+
+	if member.WorkspaceRole == membership.WorkspaceRole.ValueString() {
+		return nil
+	}
+
+	_, err = c.UpdateWorkspaceMember(ctx, membership.WorkspaceID.ValueString(), membership.UserID.ValueString(), &adminapi.UpdateWorkspaceMemberRequest{
+		WorkspaceRole: membership.WorkspaceRole.ValueString(),
+	})
+	return err
+}
+
+// plannedMemberships returns the memberships planned by ModifyPlan, resolving
+// them now if they could not be planned, for example because members were
+// unknown until apply.
+func (r *TeamResource) plannedMemberships(ctx context.Context, c adminapi.AdminAPI, data TeamResourceModel) (map[string]TeamMembershipModel, diag.Diagnostics) {
+	if !data.Memberships.IsUnknown() {
+		return r.getMemberships(ctx, data)
+	}
+
+	desired, _, diags := r.desiredMemberships(ctx, c, data)
+	return desired, diags
+}
+
+// desiredMemberships resolves the members of the team to user IDs and returns
+// the memberships of every member in every workspace, keyed by
+// workspace_id/user_id, together with the user IDs of organization admins.
+func (r *TeamResource) desiredMemberships(ctx context.Context, c adminapi.AdminAPI, data TeamResourceModel) (map[string]TeamMembershipModel, map[string]bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var members []string
+	diags.Append(data.Members.ElementsAs(ctx, &members, false)...)
+	workspaces := map[string]string{}
+	diags.Append(data.Workspaces.ElementsAs(ctx, &workspaces, false)...)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	orgMembers, err := c.ListAllOrganizationMembers(ctx)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list organization members: %s", err))
+		return nil, nil, diags
+	}
+
+	userIDs := map[string]string{}
+	admins := map[string]bool{}
+	var missing []string
+	for _, member := range members {
+		var found *adminapi.OrganizationMember
+		for i, orgMember := range orgMembers {
+			if orgMember.ID == member || (strings.Contains(member, "@") && strings.EqualFold(orgMember.Email, member)) {
+				found = &orgMembers[i]
+				break
+			}
+		}
+		if found == nil {
+			missing = append(missing, member)
+			continue
+		}
+		userIDs[member] = found.ID
+		admins[found.ID] = found.Role == "admin"
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		diags.AddAttributeError(
+			path.Root("members"),
+			"User Not Found",
+			fmt.Sprintf("The following members are not members of the organization: %s. Invite them with anthropic_invite and wait for the invites to be accepted before adding them to a team.", strings.Join(missing, ", ")),
+		)
+		return nil, nil, diags
+	}
+
+	desired := map[string]TeamMembershipModel{}
+	for _, member := range sortedKeys(userIDs) {
+		userID := userIDs[member]
+		for workspaceID, role := range workspaces {
+			key := fmt.Sprintf("%s/%s", workspaceID, userID)
+			if existing, ok := desired[key]; ok {
+				diags.AddAttributeError(
+					path.Root("members"),
+					"Duplicate Team Member",
+					fmt.Sprintf("Members %s and %s are the same user %s. Each user must be listed once.", existing.Member.ValueString(), member, userID),
+				)
+				return nil, nil, diags
+			}
+
+			desired[key] = TeamMembershipModel{
+				WorkspaceID:   types.StringValue(workspaceID),
+				UserID:        types.StringValue(userID),
+				Member:        types.StringValue(member),
+				WorkspaceRole: types.StringValue(role),
+			}
+		}
+	}
+
+	return desired, admins, diags
+}
+
+// getMemberships decodes the memberships attribute, treating null and unknown as empty.
+func (r *TeamResource) getMemberships(ctx context.Context, data TeamResourceModel) (map[string]TeamMembershipModel, diag.Diagnostics) {
+	memberships := map[string]TeamMembershipModel{}
+	if data.Memberships.IsNull() || data.Memberships.IsUnknown() {
+		return memberships, nil
+	}
+
+	diags := data.Memberships.ElementsAs(ctx, &memberships, false)
+	return memberships, diags
+}
+
+// setMemberships encodes memberships into the memberships attribute of data.
+func (r *TeamResource) setMemberships(ctx context.Context, data *TeamResourceModel, memberships map[string]TeamMembershipModel) diag.Diagnostics {
+	value, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: teamMembershipAttrTypes}, memberships)
+	data.Memberships = value
+	return diags
+}
+
+// teamConfigKnown reports whether the members and workspaces of a team are
+// fully known, so that its memberships can be planned.
+func teamConfigKnown(data TeamResourceModel) bool {
+	if data.Members.IsUnknown() || data.Workspaces.IsUnknown() {
+		return false
+	}
+
+	for _, element := range data.Members.Elements() {
+		if element.IsUnknown() {
+			return false
+		}
+	}
+	for _, element := range data.Workspaces.Elements() {
+		if element.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi/fake"
+)
+
+// teamMemberships builds memberships keyed by workspace_id/user_id from
+// workspace, user and role triples.
+func teamMemberships(triples ...[3]string) map[string]TeamMembershipModel {
+	memberships := map[string]TeamMembershipModel{}
+	for _, triple := range triples {
+		memberships[fmt.Sprintf("%s/%s", triple[0], triple[1])] = TeamMembershipModel{
+			WorkspaceID:   types.StringValue(triple[0]),
+			UserID:        types.StringValue(triple[1]),
+			Member:        types.StringValue(triple[1]),
+			WorkspaceRole: types.StringValue(triple[2]),
+		}
+	}
+	return memberships
+}
+
+// newTeamFixture returns a fake with two workspaces and three users, and a
+// team resource using it.
+func newTeamFixture(t *testing.T) (*fake.Client, *TeamResource, string, string) {
+	t.Helper()
+
+	f := fake.NewClient()
+	var ids []string
+	for _, name := range []string{"production", "staging"} {
+		workspace, err := f.CreateWorkspace(context.Background(), &adminapi.CreateWorkspaceRequest{Name: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, workspace.ID)
+	}
+	for _, id := range []string{"user_ada", "user_bob", "user_eve"} {
+		f.AddOrganizationMember(adminapi.OrganizationMember{ID: id, Email: id + "@example.com", Role: "user"})
+	}
+
+	return f, &TeamResource{providerData: newProviderData(f)}, ids[0], ids[1]
+}
+
+// workspaceRoles returns the role of every membership in the fake, keyed by
+// workspace_id/user_id.
+func workspaceRoles(t *testing.T, f *fake.Client, workspaceIDs ...string) map[string]string {
+	t.Helper()
+
+	roles := map[string]string{}
+	for _, workspaceID := range workspaceIDs {
+		members, err := f.ListAllWorkspaceMembers(context.Background(), workspaceID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, member := range members {
+			roles[workspaceID+"/"+member.UserID] = member.WorkspaceRole
+		}
+	}
+	return roles
+}
+
+func TestTeamReconcile(t *testing.T) {
+	f, r, production, staging := newTeamFixture(t)
+	ctx := context.Background()
+
+	current := teamMemberships(
+		[3]string{production, "user_ada", "workspace_user"},
+		[3]string{production, "user_bob", "workspace_user"},
+	)
+	for _, membership := range current {
+		f.AddWorkspaceMember(ctx, membership.WorkspaceID.ValueString(), &adminapi.AddWorkspaceMemberRequest{
+			UserID:        membership.UserID.ValueString(),
+			WorkspaceRole: membership.WorkspaceRole.ValueString(),
+		})
+	}
+
+	desired := teamMemberships(
+		[3]string{production, "user_ada", "workspace_developer"},
+		[3]string{staging, "user_ada", "workspace_user"},
+	)
+
+	got, failures := r.reconcile(ctx, f, desired, current, false)
+	if failures != nil {
+		t.Fatalf("reconcile() failures = %v", failures)
+	}
+	if !reflect.DeepEqual(got, desired) {
+		t.Errorf("reconcile() = %v, want %v", got, desired)
+	}
+
+	want := map[string]string{
+		production + "/user_ada": "workspace_developer",
+		staging + "/user_ada":    "workspace_user",
+	}
+	if roles := workspaceRoles(t, f, production, staging); !reflect.DeepEqual(roles, want) {
+		t.Errorf("memberships = %v, want %v", roles, want)
+	}
+}
+
+func TestTeamReconcileExistingMembership(t *testing.T) {
+	for _, adoptExisting := range []bool{false, true} {
+		t.Run(fmt.Sprintf("adopt_existing=%t", adoptExisting), func(t *testing.T) {
+			f, r, production, _ := newTeamFixture(t)
+			ctx := context.Background()
+
+			// A membership created outside of the team, such as by SSO
+			f.AddWorkspaceMember(ctx, production, &adminapi.AddWorkspaceMemberRequest{UserID: "user_eve", WorkspaceRole: "workspace_user"})
+
+			desired := teamMemberships([3]string{production, "user_eve", "workspace_developer"})
+
+			got, failures := r.reconcile(ctx, f, desired, map[string]TeamMembershipModel{}, adoptExisting)
+
+			role := workspaceRoles(t, f, production)[production+"/user_eve"]
+			if adoptExisting {
+				if failures != nil || !reflect.DeepEqual(got, desired) {
+					t.Fatalf("reconcile() = %v, %v, want the membership adopted", got, failures)
+				}
+				if role != "workspace_developer" {
+					t.Errorf("role of the adopted membership = %s, want workspace_developer", role)
+				}
+				return
+			}
+
+			if len(failures) != 1 || len(got) != 0 {
+				t.Fatalf("reconcile() = %v, %v, want the existing membership left alone", got, failures)
+			}
+			if role != "workspace_user" {
+				t.Errorf("role of the existing membership = %s, want workspace_user", role)
+			}
+		})
+	}
+}
+
+func TestTeamReconcilePartialFailure(t *testing.T) {
+	f, r, production, staging := newTeamFixture(t)
+	ctx := context.Background()
+
+	current := teamMemberships([3]string{production, "user_bob", "workspace_user"})
+	f.AddWorkspaceMember(ctx, production, &adminapi.AddWorkspaceMemberRequest{UserID: "user_bob", WorkspaceRole: "workspace_user"})
+	f.Errors["RemoveWorkspaceMember"] = errors.New("connection reset")
+
+	desired := teamMemberships([3]string{staging, "user_ada", "workspace_user"})
+
+	got, failures := r.reconcile(ctx, f, desired, current, false)
+	if len(failures) != 1 {
+		t.Errorf("reconcile() failures = %v, want the failed removal", failures)
+	}
+
+	// The membership that could not be removed is kept so it is retried
+	want := teamMemberships(
+		[3]string{production, "user_bob", "workspace_user"},
+		[3]string{staging, "user_ada", "workspace_user"},
+	)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("reconcile() = %v, want %v", got, want)
+	}
+}
+
+func newTeamPlan(members []string, workspaces map[string]string) TeamResourceModel {
+	memberValues := make([]attr.Value, 0, len(members))
+	for _, member := range members {
+		memberValues = append(memberValues, types.StringValue(member))
+	}
+	workspaceValues := map[string]attr.Value{}
+	for workspaceID, role := range workspaces {
+		workspaceValues[workspaceID] = types.StringValue(role)
+	}
+
+	return TeamResourceModel{
+		ID:                 types.StringUnknown(),
+		Members:            types.SetValueMust(types.StringType, memberValues),
+		Workspaces:         types.MapValueMust(types.StringType, workspaceValues),
+		Memberships:        types.MapUnknown(types.ObjectType{AttrTypes: teamMembershipAttrTypes}),
+		Organization:       types.StringNull(),
+		DeletionProtection: types.BoolValue(false),
+		AdoptExisting:      types.BoolValue(false),
+	}
+}
+
+func TestTeamCreatePartialFailure(t *testing.T) {
+	f, r, production, staging := newTeamFixture(t)
+
+	// Adding ada to production conflicts with an existing membership
+	f.AddWorkspaceMember(context.Background(), production, &adminapi.AddWorkspaceMemberRequest{UserID: "user_ada", WorkspaceRole: "workspace_user"})
+
+	plan := newTeamPlan([]string{"user_ada@example.com"}, map[string]string{production: "workspace_developer", staging: "workspace_developer"})
+	resp := testCreate(t, r, planOf(t, r, plan))
+	requireNoErrors(t, "Create", resp.Diagnostics)
+	if got, want := summaries(resp.Diagnostics, diag.SeverityWarning), []string{"Team Membership Not Applied"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Create warnings = %v, want %v", got, want)
+	}
+
+	var created TeamResourceModel
+	getState(t, resp.State, &created)
+	memberships, diags := r.getMemberships(context.Background(), created)
+	requireNoErrors(t, "memberships", diags)
+	if got, want := sortedKeys(memberships), []string{staging + "/user_ada"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("memberships after create = %v, want %v", got, want)
+	}
+}
+
+func TestTeamCreateNothingApplied(t *testing.T) {
+	f, r, production, staging := newTeamFixture(t)
+	f.Errors["AddWorkspaceMember"] = errors.New("connection reset")
+
+	plan := newTeamPlan([]string{"user_ada", "user_bob"}, map[string]string{production: "workspace_user", staging: "workspace_user"})
+	resp := testCreate(t, r, planOf(t, r, plan))
+	if got := len(summaries(resp.Diagnostics, diag.SeverityError)); got != 4 {
+		t.Errorf("Create reported %d errors, want 4: %v", got, resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("Create saved state although no membership was added")
+	}
+}
+
+func TestTeamUpdatePartialFailure(t *testing.T) {
+	f, r, production, staging := newTeamFixture(t)
+
+	state := newTeamPlan([]string{"user_ada"}, map[string]string{production: "workspace_user"})
+	state.ID = types.StringValue("team")
+	resp := testCreate(t, r, planOf(t, r, state))
+	requireNoErrors(t, "Create", resp.Diagnostics)
+
+	// The update fails to add bob, and saves the role change of ada
+	f.AddWorkspaceMember(context.Background(), production, &adminapi.AddWorkspaceMemberRequest{UserID: "user_bob", WorkspaceRole: "workspace_user"})
+	plan := newTeamPlan([]string{"user_ada", "user_bob"}, map[string]string{production: "workspace_developer"})
+	plan.ID = types.StringValue("team")
+
+	update := testUpdate(t, r, planOf(t, r, plan), resp.State)
+	if got, want := summaries(update.Diagnostics, diag.SeverityError), []string{"Client Error", "Team Partially Applied"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Update errors = %v, want %v", got, want)
+	}
+
+	var updated TeamResourceModel
+	getState(t, update.State, &updated)
+	memberships, diags := r.getMemberships(context.Background(), updated)
+	requireNoErrors(t, "memberships", diags)
+	if got, want := sortedKeys(memberships), []string{production + "/user_ada"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("memberships after update = %v, want %v", got, want)
+	}
+	if got := workspaceRoles(t, f, production, staging)[production+"/user_ada"]; got != "workspace_developer" {
+		t.Errorf("role of ada = %s, want workspace_developer", got)
+	}
+}
@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/terraform-mars/terraform-provider-anthropic/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan starts a span for an operation of a resource or data source.
+func startSpan(ctx context.Context, typeName, operation string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, typeName+"."+operation, trace.WithAttributes(
+		attribute.String("terraform.type", typeName),
+		attribute.String("terraform.operation", operation),
+	))
+}
+
+// endSpan ends span, marking it failed when diags contain an error.
+func endSpan(span trace.Span, diags diag.Diagnostics) {
+	if errs := diags.Errors(); len(errs) > 0 {
+		span.SetStatus(codes.Error, errs[0].Summary())
+	}
+	span.End()
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+)
+
+// reservedHeaders are set by the client and cannot be overridden by extra_headers.
+var reservedHeaders = []string{"x-api-key", "anthropic-version", "anthropic-beta", "content-type", "idempotency-key", "user-agent"}
+
+// transportOptions builds the client transport options from the provider
+// configuration. It reports whether any option differs from the defaults.
+func transportOptions(config AnthropicProviderModel) (adminapi.TransportOptions, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	opts := adminapi.TransportOptions{
+		ProxyURL:           config.ProxyURL.ValueString(),
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+	}
+
+	var err error
+	if opts.CACertPEM, err = readPEM(config.CACertPEM, config.CACertFile); err != nil {
+		diags.AddAttributeError(path.Root("ca_cert_file"), "Unable to Read CA Certificate", err.Error())
+	}
+	if opts.ClientCertPEM, err = readPEM(config.ClientCertPEM, config.ClientCertFile); err != nil {
+		diags.AddAttributeError(path.Root("client_cert_file"), "Unable to Read Client Certificate", err.Error())
+	}
+	if opts.ClientKeyPEM, err = readPEM(config.ClientKeyPEM, config.ClientKeyFile); err != nil {
+		diags.AddAttributeError(path.Root("client_key_file"), "Unable to Read Client Key", err.Error())
+	}
+
+	if (len(opts.ClientCertPEM) > 0) != (len(opts.ClientKeyPEM) > 0) {
+		diags.AddError(
+			"Incomplete Client Certificate",
+			"A client certificate and its private key must be configured together: set client_cert_pem or client_cert_file along with client_key_pem or client_key_file.",
+		)
+	}
+
+	custom := opts.ProxyURL != "" || opts.InsecureSkipVerify || len(opts.CACertPEM) > 0 || len(opts.ClientCertPEM) > 0
+	return opts, custom, diags
+}
+
+// readPEM returns the PEM value if set, otherwise the contents of the file.
+func readPEM(pem, file types.String) ([]byte, error) {
+	if pem.ValueString() != "" {
+		return []byte(pem.ValueString()), nil
+	}
+	if file.ValueString() == "" {
+		return nil, nil
+	}
+
+	contents, err := os.ReadFile(file.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", file.ValueString(), err)
+	}
+	return contents, nil
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/terraform-mars/terraform-provider-anthropic/internal/client"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -19,16 +18,17 @@ func NewWorkspaceDataSource() datasource.DataSource {
 
 // WorkspaceDataSource defines the data source implementation.
 type WorkspaceDataSource struct {
-	client *client.Client
+	providerData *AnthropicProviderData
 }
 
 // WorkspaceDataSourceModel describes the data source data model.
 type WorkspaceDataSourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	DisplayName types.String `tfsdk:"display_name"`
-	CreatedAt   types.String `tfsdk:"created_at"`
-	ArchivedAt  types.String `tfsdk:"archived_at"`
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	DisplayName  types.String `tfsdk:"display_name"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	ArchivedAt   types.String `tfsdk:"archived_at"`
+	Organization types.String `tfsdk:"organization"`
 }
 
 func (d *WorkspaceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -59,6 +59,10 @@ func (d *WorkspaceDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				Description: "The timestamp when the workspace was archived, if applicable.",
 				Computed:    true,
 			},
+			"organization": schema.StringAttribute{
+				Description: "The name of an entry in the provider's organizations map whose credentials are used for this data source. Defaults to the provider's admin_key.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -68,19 +72,22 @@ func (d *WorkspaceDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*AnthropicProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.AnthropicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = c
+	d.providerData = providerData
 }
 
 func (d *WorkspaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "anthropic_workspace", "read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data WorkspaceDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -89,7 +96,13 @@ func (d *WorkspaceDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	workspace, err := d.client.GetWorkspace(ctx, data.ID.ValueString())
+	c, diags := d.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workspace, err := c.GetWorkspace(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workspace: %s", err))
 		return
@@ -4,21 +4,25 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/terraform-mars/terraform-provider-anthropic/internal/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &WorkspaceMemberResource{}
 var _ resource.ResourceWithImportState = &WorkspaceMemberResource{}
+var _ resource.ResourceWithModifyPlan = &WorkspaceMemberResource{}
 
 func NewWorkspaceMemberResource() resource.Resource {
 	return &WorkspaceMemberResource{}
@@ -26,15 +30,19 @@ func NewWorkspaceMemberResource() resource.Resource {
 
 // WorkspaceMemberResource defines the resource implementation.
 type WorkspaceMemberResource struct {
-	client *client.Client
+	providerData *AnthropicProviderData
 }
 
 // WorkspaceMemberResourceModel describes the resource data model.
 type WorkspaceMemberResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	WorkspaceID   types.String `tfsdk:"workspace_id"`
-	UserID        types.String `tfsdk:"user_id"`
-	WorkspaceRole types.String `tfsdk:"workspace_role"`
+	ID                 types.String `tfsdk:"id"`
+	WorkspaceID        types.String `tfsdk:"workspace_id"`
+	UserID             types.String `tfsdk:"user_id"`
+	Email              types.String `tfsdk:"email"`
+	WorkspaceRole      types.String `tfsdk:"workspace_role"`
+	Organization       types.String `tfsdk:"organization"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	AdoptExisting      types.Bool   `tfsdk:"adopt_existing"`
 }
 
 func (r *WorkspaceMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,12 +68,21 @@ func (r *WorkspaceMemberResource) Schema(ctx context.Context, req resource.Schem
 				},
 			},
 			"user_id": schema.StringAttribute{
-				Description: "The ID of the user to add to the workspace.",
-				Required:    true,
+				Description: "The ID of the user to add to the workspace. Exactly one of user_id and email must be set; when email is set, this is the ID of the organization member with that email.",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"email": schema.StringAttribute{
+				Description: "The email address of the organization member to add to the workspace, as an alternative to user_id. The user must already be a member of the organization.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("user_id")),
+				},
+			},
 			"workspace_role": schema.StringAttribute{
 				Description: "The role of the user in the workspace. Valid values: workspace_user, workspace_admin, workspace_developer.",
 				Required:    true,
@@ -73,6 +90,25 @@ func (r *WorkspaceMemberResource) Schema(ctx context.Context, req resource.Schem
 					stringvalidator.OneOf("workspace_user", "workspace_admin", "workspace_developer"),
 				},
 			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether destroying or replacing the membership is prevented. Must be set to false in a separate apply before the membership can be destroyed. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Whether to take over an existing membership of the user in the workspace, such as one created by SSO auto-provisioning, instead of failing. The role of an adopted membership is updated to workspace_role. Only used when the resource is created. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"organization": schema.StringAttribute{
+				Description: "The name of an entry in the provider's organizations map whose credentials are used for this resource. Defaults to the provider's admin_key. Changing it forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 		},
 	}
 }
@@ -82,19 +118,141 @@ func (r *WorkspaceMemberResource) Configure(ctx context.Context, req resource.Co
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*AnthropicProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.AnthropicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *WorkspaceMemberResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx, span := startSpan(ctx, "anthropic_workspace_member", "plan")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	// Nothing to validate on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.providerData == nil {
+		return
+	}
+
+	var plan WorkspaceMemberResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state WorkspaceMemberResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Resolve email to user_id whenever the email is set or changed, so that
+	// the plan shows the user and replaces the membership if it is a new one
+	var member *adminapi.OrganizationMember
+	if !plan.Email.IsNull() && !plan.Email.Equal(state.Email) {
+		if plan.Email.IsUnknown() {
+			// The email is resolved on apply instead
+			plan.UserID = types.StringUnknown()
+			if !req.State.Raw.IsNull() {
+				resp.RequiresReplace = append(resp.RequiresReplace, path.Root("user_id"))
+			}
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+			return
+		}
+
+		c, diags := r.providerData.clientFor(plan.Organization)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var err error
+		member, err = findOrganizationMemberByEmail(ctx, c, plan.Email.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", err.Error())
+			return
+		}
+		if member == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("email"),
+				"User Not Found",
+				fmt.Sprintf("No member of the organization has the email %s. Invite the user with anthropic_invite and wait for the invite to be accepted before adding them to a workspace.", plan.Email.ValueString()),
+			)
+			return
+		}
+
+		plan.UserID = types.StringValue(member.ID)
+		if !req.State.Raw.IsNull() && !plan.UserID.Equal(state.UserID) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("user_id"))
+		}
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if plan.WorkspaceID.IsUnknown() || plan.UserID.IsUnknown() {
+		return
+	}
+
+	key := fmt.Sprintf("%s/%s", plan.WorkspaceID.ValueString(), plan.UserID.ValueString())
+	if !r.providerData.memberships.register(key) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_id"),
+			"Duplicate Workspace Membership",
+			fmt.Sprintf("User %s is declared as a member of workspace %s by more than one resource. Each membership must be managed by a single anthropic_workspace_member, anthropic_team or anthropic_roster resource.", plan.UserID.ValueString(), plan.WorkspaceID.ValueString()),
 		)
 		return
 	}
 
-	r.client = c
+	// Only look the user up when the membership or role is about to change
+	if plan.UserID.Equal(state.UserID) && plan.WorkspaceRole.Equal(state.WorkspaceRole) {
+		return
+	}
+
+	c, diags := r.providerData.clientFor(plan.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if member == nil {
+		var err error
+		member, err = c.GetOrganizationMember(ctx, plan.UserID.ValueString())
+		if adminapi.IsNotFound(err) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("user_id"),
+				"User Not Found",
+				fmt.Sprintf("User %s is not a member of the organization. Invite the user with anthropic_invite and wait for the invite to be accepted before adding them to a workspace.", plan.UserID.ValueString()),
+			)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organization member: %s", err))
+			return
+		}
+	}
+
+	if member.Role == "admin" && !plan.WorkspaceRole.IsUnknown() && plan.WorkspaceRole.ValueString() != "workspace_admin" {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("workspace_role"),
+			"Workspace Role Overridden by Organization Role",
+			fmt.Sprintf("User %s is an organization admin and is always a workspace_admin of every workspace. The API will report workspace_admin instead of %s, which will show up as a difference on every plan.", plan.UserID.ValueString(), plan.WorkspaceRole.ValueString()),
+		)
+	}
 }
 
 func (r *WorkspaceMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "anthropic_workspace_member", "create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data WorkspaceMemberResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -103,15 +261,59 @@ func (r *WorkspaceMemberResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	member, err := r.client.AddWorkspaceMember(ctx, data.WorkspaceID.ValueString(), &client.AddWorkspaceMemberRequest{
-		UserID:        data.UserID.ValueString(),
-		WorkspaceRole: data.WorkspaceRole.ValueString(),
-	})
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add workspace member: %s", err))
+	resp.Diagnostics.Append(r.providerData.checkWritable("create workspace member")...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The email could not be resolved while planning if it was unknown
+	if data.UserID.IsUnknown() {
+		orgMember, err := findOrganizationMemberByEmail(ctx, c, data.Email.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", err.Error())
+			return
+		}
+		if orgMember == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("email"),
+				"User Not Found",
+				fmt.Sprintf("No member of the organization has the email %s.", data.Email.ValueString()),
+			)
+			return
+		}
+		data.UserID = types.StringValue(orgMember.ID)
+	}
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_workspace_member", fmt.Sprintf("%s/%s", data.WorkspaceID.ValueString(), data.UserID.ValueString()))
+
+	var member *adminapi.WorkspaceMember
+	if data.AdoptExisting.ValueBool() {
+		var err error
+		member, err = r.adoptMember(ctx, c, data)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to adopt workspace member: %s", err))
+			return
+		}
+	}
+
+	if member == nil {
+		var err error
+		member, err = c.AddWorkspaceMember(ctx, data.WorkspaceID.ValueString(), &adminapi.AddWorkspaceMemberRequest{
+			UserID:        data.UserID.ValueString(),
+			WorkspaceRole: data.WorkspaceRole.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add workspace member: %s", err))
+			return
+		}
+	}
+
 	data.ID = types.StringValue(fmt.Sprintf("%s/%s", member.WorkspaceID, member.UserID))
 	data.WorkspaceID = types.StringValue(member.WorkspaceID)
 	data.UserID = types.StringValue(member.UserID)
@@ -121,6 +323,9 @@ func (r *WorkspaceMemberResource) Create(ctx context.Context, req resource.Creat
 }
 
 func (r *WorkspaceMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "anthropic_workspace_member", "read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data WorkspaceMemberResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -129,7 +334,13 @@ func (r *WorkspaceMemberResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	member, err := r.client.GetWorkspaceMember(ctx, data.WorkspaceID.ValueString(), data.UserID.ValueString())
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, err := c.GetWorkspaceMember(ctx, data.WorkspaceID.ValueString(), data.UserID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workspace member: %s", err))
 		return
@@ -137,19 +348,52 @@ func (r *WorkspaceMemberResource) Read(ctx context.Context, req resource.ReadReq
 
 	data.WorkspaceRole = types.StringValue(member.WorkspaceRole)
 
+	// Imported resources have no deletion_protection or adopt_existing in state yet
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *WorkspaceMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "anthropic_workspace_member", "update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data WorkspaceMemberResourceModel
+	var state WorkspaceMemberResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	resp.Diagnostics.Append(r.providerData.checkWritable("update workspace member")...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	member, err := r.client.UpdateWorkspaceMember(ctx, data.WorkspaceID.ValueString(), data.UserID.ValueString(), &client.UpdateWorkspaceMemberRequest{
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_workspace_member", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Changes to local-only attributes such as deletion_protection, or to an
+	// email of the same user, need no API call
+	if data.WorkspaceRole.Equal(state.WorkspaceRole) {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	member, err := c.UpdateWorkspaceMember(ctx, data.WorkspaceID.ValueString(), data.UserID.ValueString(), &adminapi.UpdateWorkspaceMemberRequest{
 		WorkspaceRole: data.WorkspaceRole.ValueString(),
 	})
 	if err != nil {
@@ -163,6 +407,9 @@ func (r *WorkspaceMemberResource) Update(ctx context.Context, req resource.Updat
 }
 
 func (r *WorkspaceMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "anthropic_workspace_member", "delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data WorkspaceMemberResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -171,7 +418,28 @@ func (r *WorkspaceMemberResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	err := r.client.RemoveWorkspaceMember(ctx, data.WorkspaceID.ValueString(), data.UserID.ValueString())
+	resp.Diagnostics.Append(r.providerData.checkWritable("destroy workspace member")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_workspace_member", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Deletion Protection Enabled",
+			fmt.Sprintf("Workspace member %s has deletion_protection enabled. Set deletion_protection to false and apply that change before destroying or replacing it.", data.ID.ValueString()),
+		)
+		return
+	}
+
+	err := c.RemoveWorkspaceMember(ctx, data.WorkspaceID.ValueString(), data.UserID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove workspace member: %s", err))
 		return
@@ -179,17 +447,74 @@ func (r *WorkspaceMemberResource) Delete(ctx context.Context, req resource.Delet
 }
 
 func (r *WorkspaceMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import ID format: workspace_id/user_id
-	parts := strings.Split(req.ID, "/")
+	ctx, span := startSpan(ctx, "anthropic_workspace_member", "import")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	// Import ID format: [<organization>:]workspace_id/user_id
+	organization, importID := r.providerData.splitImportOrganization(req.ID)
+
+	parts := strings.Split(importID, "/")
 	if len(parts) != 2 {
 		resp.Diagnostics.AddError(
 			"Invalid Import ID",
-			fmt.Sprintf("Expected import ID format: workspace_id/user_id, got: %s", req.ID),
+			fmt.Sprintf("Expected import ID format: [<organization>:]workspace_id/user_id, got: %s", req.ID),
 		)
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), importID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization"), organization)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_id"), parts[0])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), parts[1])...)
 }
+
+// adoptMember returns the existing membership of the planned user in the
+// planned workspace with its role reconciled to the planned role, or nil if
+// the user is not a member yet.
+func (r *WorkspaceMemberResource) adoptMember(ctx context.Context, c adminapi.AdminAPI, data WorkspaceMemberResourceModel) (*adminapi.WorkspaceMember, error) {
+	member, err := c.GetWorkspaceMember(ctx, data.WorkspaceID.ValueString(), data.UserID.ValueString())
+	if adminapi.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Info(ctx, "Adopting existing workspace membership", map[string]interface{}{
+		"workspace_id":   member.WorkspaceID,
+		"user_id":        member.UserID,
+		"workspace_role": member.WorkspaceRole,
+	})
+
+	if member.WorkspaceRole == data.WorkspaceRole.ValueString() {
+		return member, nil
+	}
+
+	return c.UpdateWorkspaceMember(ctx, member.WorkspaceID, member.UserID, &adminapi.UpdateWorkspaceMemberRequest{
+		WorkspaceRole: data.WorkspaceRole.ValueString(),
+	})
+}
+
+// membershipRegistry records the workspace memberships planned by a provider
+// instance. Terraform plans every resource instance once per provider
+// instance, so a membership registered twice is declared by two resources.
+type membershipRegistry struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func newMembershipRegistry() *membershipRegistry {
+	return &membershipRegistry{seen: map[string]bool{}}
+}
+
+// register records key and reports whether it was not registered before.
+func (m *membershipRegistry) register(key string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.seen[key] {
+		return false
+	}
+	m.seen[key] = true
+	return true
+}
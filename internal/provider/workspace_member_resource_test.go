@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi/fake"
+)
+
+// newWorkspaceMemberFixture returns a fake with a production workspace and an
+// organization member ada, and the plan adding ada to the workspace.
+func newWorkspaceMemberFixture(t *testing.T) (*fake.Client, WorkspaceMemberResourceModel) {
+	t.Helper()
+
+	f := fake.NewClient()
+	f.AddOrganizationMember(adminapi.OrganizationMember{ID: "user_ada", Email: "ada@example.com", Role: "user"})
+	workspace, err := f.CreateWorkspace(context.Background(), &adminapi.CreateWorkspaceRequest{Name: "production"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return f, WorkspaceMemberResourceModel{
+		ID:                 types.StringUnknown(),
+		WorkspaceID:        types.StringValue(workspace.ID),
+		UserID:             types.StringValue("user_ada"),
+		Email:              types.StringNull(),
+		WorkspaceRole:      types.StringValue("workspace_developer"),
+		Organization:       types.StringNull(),
+		DeletionProtection: types.BoolValue(false),
+		AdoptExisting:      types.BoolValue(false),
+	}
+}
+
+// addWorkspaceMember adds the member planned in data to f and returns its state.
+func addWorkspaceMember(t *testing.T, f *fake.Client, data WorkspaceMemberResourceModel) WorkspaceMemberResourceModel {
+	t.Helper()
+
+	_, err := f.AddWorkspaceMember(context.Background(), data.WorkspaceID.ValueString(), &adminapi.AddWorkspaceMemberRequest{
+		UserID:        data.UserID.ValueString(),
+		WorkspaceRole: data.WorkspaceRole.ValueString(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data.ID = types.StringValue(data.WorkspaceID.ValueString() + "/" + data.UserID.ValueString())
+	return data
+}
+
+func TestWorkspaceMemberUpdateLocalOnly(t *testing.T) {
+	f, plan := newWorkspaceMemberFixture(t)
+	r := &WorkspaceMemberResource{providerData: newProviderData(f)}
+
+	state := addWorkspaceMember(t, f, plan)
+	plan = state
+	plan.DeletionProtection = types.BoolValue(true)
+
+	// Changing only deletion_protection must not call the API
+	f.Errors["UpdateWorkspaceMember"] = errors.New("unexpected update")
+
+	resp := testUpdate(t, r, planOf(t, r, plan), stateOf(t, r, state))
+	requireNoErrors(t, "Update", resp.Diagnostics)
+
+	var data WorkspaceMemberResourceModel
+	getState(t, resp.State, &data)
+	if !data.DeletionProtection.ValueBool() {
+		t.Error("Update did not save deletion_protection")
+	}
+}
+
+func TestWorkspaceMemberDeleteProtected(t *testing.T) {
+	f, plan := newWorkspaceMemberFixture(t)
+	r := &WorkspaceMemberResource{providerData: newProviderData(f)}
+
+	data := addWorkspaceMember(t, f, plan)
+	data.DeletionProtection = types.BoolValue(true)
+
+	resp := testDelete(t, r, stateOf(t, r, data))
+	if got := summaries(resp.Diagnostics, diag.SeverityError); !reflect.DeepEqual(got, []string{"Deletion Protection Enabled"}) {
+		t.Errorf("Delete errors = %v, want Deletion Protection Enabled", got)
+	}
+
+	if _, err := f.GetWorkspaceMember(context.Background(), data.WorkspaceID.ValueString(), "user_ada"); err != nil {
+		t.Errorf("protected workspace member was removed: %s", err)
+	}
+}
+
+func TestWorkspaceMemberCreateAdoptExisting(t *testing.T) {
+	tests := []struct {
+		name          string
+		adoptExisting bool
+		wantErrors    []string
+		wantRole      string
+	}{
+		// An existing membership is taken over with its role reconciled
+		{name: "adopt", adoptExisting: true, wantRole: "workspace_developer"},
+		{name: "conflict", adoptExisting: false, wantErrors: []string{"Client Error"}, wantRole: "workspace_user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, plan := newWorkspaceMemberFixture(t)
+			r := &WorkspaceMemberResource{providerData: newProviderData(f)}
+
+			// The user was added by SSO auto-provisioning with another role
+			existing := plan
+			existing.WorkspaceRole = types.StringValue("workspace_user")
+			addWorkspaceMember(t, f, existing)
+
+			plan.AdoptExisting = types.BoolValue(tt.adoptExisting)
+			resp := testCreate(t, r, planOf(t, r, plan))
+			if got := summaries(resp.Diagnostics, diag.SeverityError); !reflect.DeepEqual(got, tt.wantErrors) {
+				t.Fatalf("Create errors = %v, want %v", got, tt.wantErrors)
+			}
+
+			member, err := f.GetWorkspaceMember(context.Background(), plan.WorkspaceID.ValueString(), "user_ada")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if member.WorkspaceRole != tt.wantRole {
+				t.Errorf("workspace_role = %s, want %s", member.WorkspaceRole, tt.wantRole)
+			}
+			if tt.wantErrors != nil {
+				return
+			}
+
+			var data WorkspaceMemberResourceModel
+			getState(t, resp.State, &data)
+			if want := plan.WorkspaceID.ValueString() + "/user_ada"; data.ID.ValueString() != want {
+				t.Errorf("id = %s, want %s", data.ID, want)
+			}
+		})
+	}
+}
+
+func TestWorkspaceMemberCreateAdoptExistingNotMember(t *testing.T) {
+	f, plan := newWorkspaceMemberFixture(t)
+	r := &WorkspaceMemberResource{providerData: newProviderData(f)}
+
+	// Without an existing membership, adopt_existing adds the user
+	plan.AdoptExisting = types.BoolValue(true)
+	resp := testCreate(t, r, planOf(t, r, plan))
+	requireNoErrors(t, "Create", resp.Diagnostics)
+
+	member, err := f.GetWorkspaceMember(context.Background(), plan.WorkspaceID.ValueString(), "user_ada")
+	if err != nil || member.WorkspaceRole != "workspace_developer" {
+		t.Errorf("GetWorkspaceMember() = %v, %v, want a workspace_developer", member, err)
+	}
+}
+
+// withEmail returns plan with the user given by email instead of user_id.
+func withEmail(plan WorkspaceMemberResourceModel, email string) WorkspaceMemberResourceModel {
+	plan.Email = types.StringValue(email)
+	plan.UserID = types.StringUnknown()
+	return plan
+}
+
+func TestWorkspaceMemberModifyPlanEmail(t *testing.T) {
+	f, plan := newWorkspaceMemberFixture(t)
+	r := &WorkspaceMemberResource{providerData: newProviderData(f)}
+
+	// Emails match regardless of case
+	resp := testModifyPlan(t, r, planOf(t, r, withEmail(plan, "Ada@Example.com")), nullState(t, r))
+	requireNoErrors(t, "ModifyPlan", resp.Diagnostics)
+
+	var planned WorkspaceMemberResourceModel
+	requireNoErrors(t, "Plan.Get", resp.Plan.Get(context.Background(), &planned))
+	if planned.UserID.ValueString() != "user_ada" {
+		t.Errorf("planned user_id = %s, want user_ada", planned.UserID)
+	}
+}
+
+func TestWorkspaceMemberModifyPlanEmailNotMember(t *testing.T) {
+	f, plan := newWorkspaceMemberFixture(t)
+	r := &WorkspaceMemberResource{providerData: newProviderData(f)}
+
+	resp := testModifyPlan(t, r, planOf(t, r, withEmail(plan, "eve@example.com")), nullState(t, r))
+	if got := summaries(resp.Diagnostics, diag.SeverityError); !reflect.DeepEqual(got, []string{"User Not Found"}) {
+		t.Errorf("ModifyPlan errors = %v, want User Not Found", got)
+	}
+}
+
+func TestWorkspaceMemberModifyPlanEmailChanged(t *testing.T) {
+	f, plan := newWorkspaceMemberFixture(t)
+	f.AddOrganizationMember(adminapi.OrganizationMember{ID: "user_bob", Email: "bob@example.com", Role: "user"})
+	r := &WorkspaceMemberResource{providerData: newProviderData(f)}
+
+	state := addWorkspaceMember(t, f, plan)
+	state.Email = types.StringValue("ada@example.com")
+
+	tests := []struct {
+		email       string
+		wantReplace bool
+	}{
+		// Another spelling of the same user's email keeps the membership
+		{email: "ADA@example.com", wantReplace: false},
+		{email: "bob@example.com", wantReplace: true},
+	}
+
+	for _, tt := range tests {
+		r.providerData.memberships = newMembershipRegistry()
+
+		next := withEmail(state, tt.email)
+		resp := testModifyPlan(t, r, planOf(t, r, next), stateOf(t, r, state))
+		requireNoErrors(t, "ModifyPlan", resp.Diagnostics)
+		if got := len(resp.RequiresReplace) > 0; got != tt.wantReplace {
+			t.Errorf("changing email to %s requires replace = %t, want %t", tt.email, got, tt.wantReplace)
+		}
+	}
+}
+
+func TestWorkspaceMemberCreateUnknownEmail(t *testing.T) {
+	f, plan := newWorkspaceMemberFixture(t)
+	r := &WorkspaceMemberResource{providerData: newProviderData(f)}
+
+	// An email unknown while planning is resolved on apply
+	resp := testCreate(t, r, planOf(t, r, withEmail(plan, "ada@example.com")))
+	requireNoErrors(t, "Create", resp.Diagnostics)
+
+	var data WorkspaceMemberResourceModel
+	getState(t, resp.State, &data)
+	if data.UserID.ValueString() != "user_ada" {
+		t.Errorf("user_id = %s, want user_ada", data.UserID)
+	}
+
+	resp = testCreate(t, r, planOf(t, r, withEmail(plan, "eve@example.com")))
+	if got := summaries(resp.Diagnostics, diag.SeverityError); !reflect.DeepEqual(got, []string{"User Not Found"}) {
+		t.Errorf("Create errors = %v, want User Not Found", got)
+	}
+}
@@ -3,14 +3,18 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/terraform-mars/terraform-provider-anthropic/internal/client"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -23,16 +27,19 @@ func NewWorkspaceResource() resource.Resource {
 
 // WorkspaceResource defines the resource implementation.
 type WorkspaceResource struct {
-	client *client.Client
+	providerData *AnthropicProviderData
 }
 
 // WorkspaceResourceModel describes the resource data model.
 type WorkspaceResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	DisplayName types.String `tfsdk:"display_name"`
-	CreatedAt   types.String `tfsdk:"created_at"`
-	ArchivedAt  types.String `tfsdk:"archived_at"`
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	DisplayName        types.String `tfsdk:"display_name"`
+	CreatedAt          types.String `tfsdk:"created_at"`
+	ArchivedAt         types.String `tfsdk:"archived_at"`
+	Organization       types.String `tfsdk:"organization"`
+	OnDestroy          types.String `tfsdk:"on_destroy"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 func (r *WorkspaceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -69,6 +76,26 @@ func (r *WorkspaceResource) Schema(ctx context.Context, req resource.SchemaReque
 				Description: "The timestamp when the workspace was archived, if applicable.",
 				Computed:    true,
 			},
+			"on_destroy": schema.StringAttribute{
+				Description: "What happens to the workspace when this resource is destroyed: archive it, deactivate all of its API keys while leaving it unarchived, or abandon it (only remove it from state). Defaults to the provider's on_destroy.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(onDestroyArchive, onDestroyDeactivate, onDestroyAbandon),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether destroying or replacing the workspace is prevented. Must be set to false in a separate apply before the workspace can be destroyed. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"organization": schema.StringAttribute{
+				Description: "The name of an entry in the provider's organizations map whose credentials are used for this resource. Defaults to the provider's admin_key. Changing it forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 		},
 	}
 }
@@ -78,19 +105,22 @@ func (r *WorkspaceResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*AnthropicProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.AnthropicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.providerData = providerData
 }
 
 func (r *WorkspaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "anthropic_workspace", "create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data WorkspaceResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -99,7 +129,20 @@ func (r *WorkspaceResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	workspace, err := r.client.CreateWorkspace(ctx, &client.CreateWorkspaceRequest{
+	resp.Diagnostics.Append(r.providerData.checkWritable("create workspace")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_workspace", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workspace, err := c.CreateWorkspace(ctx, &adminapi.CreateWorkspaceRequest{
 		Name: data.Name.ValueString(),
 	})
 	if err != nil {
@@ -121,6 +164,9 @@ func (r *WorkspaceResource) Create(ctx context.Context, req resource.CreateReque
 }
 
 func (r *WorkspaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "anthropic_workspace", "read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data WorkspaceResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -129,7 +175,13 @@ func (r *WorkspaceResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	workspace, err := r.client.GetWorkspace(ctx, data.ID.ValueString())
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workspace, err := c.GetWorkspace(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workspace: %s", err))
 		return
@@ -144,19 +196,50 @@ func (r *WorkspaceResource) Read(ctx context.Context, req resource.ReadRequest,
 		data.ArchivedAt = types.StringNull()
 	}
 
+	// Imported resources have no deletion_protection in state yet
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *WorkspaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "anthropic_workspace", "update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data WorkspaceResourceModel
+	var state WorkspaceResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	workspace, err := r.client.UpdateWorkspace(ctx, data.ID.ValueString(), &client.UpdateWorkspaceRequest{
+	resp.Diagnostics.Append(r.providerData.checkWritable("update workspace")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_workspace", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Changes to local-only attributes such as on_destroy need no API call
+	if data.Name.Equal(state.Name) {
+		data.DisplayName = state.DisplayName
+		data.ArchivedAt = state.ArchivedAt
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	workspace, err := c.UpdateWorkspace(ctx, data.ID.ValueString(), &adminapi.UpdateWorkspaceRequest{
 		Name: data.Name.ValueString(),
 	})
 	if err != nil {
@@ -176,6 +259,9 @@ func (r *WorkspaceResource) Update(ctx context.Context, req resource.UpdateReque
 }
 
 func (r *WorkspaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "anthropic_workspace", "delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data WorkspaceResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -184,14 +270,82 @@ func (r *WorkspaceResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	// Archive the workspace instead of deleting
-	_, err := r.client.ArchiveWorkspace(ctx, data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to archive workspace: %s", err))
+	resp.Diagnostics.Append(r.providerData.checkWritable("destroy workspace")...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	ctx = adminapi.ContextWithResource(ctx, "anthropic_workspace", data.ID.ValueString())
+
+	c, diags := r.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Deletion Protection Enabled",
+			fmt.Sprintf("Workspace %s has deletion_protection enabled. Set deletion_protection to false and apply that change before destroying or replacing it.", data.ID.ValueString()),
+		)
+		return
+	}
+
+	switch r.providerData.onDestroyFor(data.OnDestroy) {
+	case onDestroyAbandon:
+		resp.Diagnostics.AddWarning(
+			"Workspace Abandoned",
+			fmt.Sprintf("Workspace %s was removed from state but left unchanged in the organization.", data.ID.ValueString()),
+		)
+	case onDestroyDeactivate:
+		// Workspaces cannot be deactivated, so deactivate their keys instead
+		apiKeys, err := c.ListAllAPIKeys(ctx, "active", data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list workspace API keys: %s", err))
+			return
+		}
+
+		for _, apiKey := range apiKeys {
+			_, err := c.UpdateAPIKey(ctx, apiKey.ID, &adminapi.UpdateAPIKeyRequest{Status: "inactive"})
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to deactivate API key %s: %s", apiKey.ID, err))
+				return
+			}
+		}
+	default:
+		// Archive the workspace instead of deleting
+		_, err := c.ArchiveWorkspace(ctx, data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to archive workspace: %s", err))
+			return
+		}
+	}
 }
 
 func (r *WorkspaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx, span := startSpan(ctx, "anthropic_workspace", "import")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	// Import ID format: [<organization>:]workspace_id or name:<workspace-name>
+	organization, importID := r.providerData.splitImportOrganization(req.ID)
+
+	// Only lookups need the API, raw IDs are imported as-is
+	id := importID
+	if strings.HasPrefix(importID, importPrefixName) {
+		c, diags := r.providerData.clientFor(organization)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var err error
+		id, err = resolveWorkspaceImportID(ctx, c, importID)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Unable to resolve workspace import ID: %s", err))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization"), organization)...)
 }
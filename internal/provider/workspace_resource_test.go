@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi/fake"
+)
+
+// newWorkspaceState creates a workspace with an active API key in f and
+// returns its state.
+func newWorkspaceState(t *testing.T, f *fake.Client) (WorkspaceResourceModel, string) {
+	t.Helper()
+	ctx := context.Background()
+
+	workspace, err := f.CreateWorkspace(ctx, &adminapi.CreateWorkspaceRequest{Name: "production"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	apiKey, err := f.CreateAPIKey(ctx, &adminapi.CreateAPIKeyRequest{Name: "ci", WorkspaceID: workspace.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return WorkspaceResourceModel{
+		ID:                 types.StringValue(workspace.ID),
+		Name:               types.StringValue(workspace.Name),
+		DisplayName:        types.StringValue(workspace.DisplayName),
+		CreatedAt:          types.StringValue(workspace.CreatedAt),
+		ArchivedAt:         types.StringNull(),
+		Organization:       types.StringNull(),
+		OnDestroy:          types.StringNull(),
+		DeletionProtection: types.BoolValue(false),
+	}, apiKey.ID
+}
+
+func TestWorkspaceDeleteOnDestroy(t *testing.T) {
+	tests := []struct {
+		name            string
+		providerDefault string
+		onDestroy       types.String
+		wantArchived    bool
+		wantKeyStatus   string
+		wantWarnings    []string
+	}{
+		{name: "default", onDestroy: types.StringNull(), wantArchived: true, wantKeyStatus: "archived"},
+		{name: "archive", providerDefault: onDestroyAbandon, onDestroy: types.StringValue(onDestroyArchive), wantArchived: true, wantKeyStatus: "archived"},
+		{name: "deactivate", onDestroy: types.StringValue(onDestroyDeactivate), wantKeyStatus: "inactive"},
+		{name: "abandon", onDestroy: types.StringValue(onDestroyAbandon), wantKeyStatus: "active", wantWarnings: []string{"Workspace Abandoned"}},
+		{name: "provider abandon", providerDefault: onDestroyAbandon, onDestroy: types.StringNull(), wantKeyStatus: "active", wantWarnings: []string{"Workspace Abandoned"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := fake.NewClient()
+			r := &WorkspaceResource{providerData: newProviderData(f)}
+			if tt.providerDefault != "" {
+				r.providerData.onDestroy = tt.providerDefault
+			}
+
+			data, apiKeyID := newWorkspaceState(t, f)
+			data.OnDestroy = tt.onDestroy
+
+			resp := testDelete(t, r, stateOf(t, r, data))
+			requireNoErrors(t, "Delete", resp.Diagnostics)
+			if got := summaries(resp.Diagnostics, diag.SeverityWarning); !reflect.DeepEqual(got, tt.wantWarnings) {
+				t.Errorf("Delete warnings = %v, want %v", got, tt.wantWarnings)
+			}
+
+			workspace, err := f.GetWorkspace(context.Background(), data.ID.ValueString())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if archived := workspace.ArchivedAt != ""; archived != tt.wantArchived {
+				t.Errorf("workspace archived = %t, want %t", archived, tt.wantArchived)
+			}
+
+			apiKey, err := f.GetAPIKey(context.Background(), apiKeyID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if apiKey.Status != tt.wantKeyStatus {
+				t.Errorf("workspace API key status = %s, want %s", apiKey.Status, tt.wantKeyStatus)
+			}
+		})
+	}
+}
+
+func TestWorkspaceUpdateLocalOnly(t *testing.T) {
+	f := fake.NewClient()
+	r := &WorkspaceResource{providerData: newProviderData(f)}
+
+	state, _ := newWorkspaceState(t, f)
+	plan := state
+	plan.OnDestroy = types.StringValue(onDestroyAbandon)
+	plan.DisplayName = types.StringUnknown()
+
+	// Changing only on_destroy must not call the API
+	f.Errors["UpdateWorkspace"] = errors.New("unexpected update")
+
+	resp := testUpdate(t, r, planOf(t, r, plan), stateOf(t, r, state))
+	requireNoErrors(t, "Update", resp.Diagnostics)
+
+	var data WorkspaceResourceModel
+	getState(t, resp.State, &data)
+	if data.OnDestroy.ValueString() != onDestroyAbandon || !data.DisplayName.Equal(state.DisplayName) {
+		t.Errorf("Update saved on_destroy %s and display_name %s, want %s and %s", data.OnDestroy, data.DisplayName, onDestroyAbandon, state.DisplayName)
+	}
+}
+
+func TestWorkspaceDeleteProtected(t *testing.T) {
+	f := fake.NewClient()
+	r := &WorkspaceResource{providerData: newProviderData(f)}
+
+	data, _ := newWorkspaceState(t, f)
+	data.DeletionProtection = types.BoolValue(true)
+
+	resp := testDelete(t, r, stateOf(t, r, data))
+	if got := summaries(resp.Diagnostics, diag.SeverityError); !reflect.DeepEqual(got, []string{"Deletion Protection Enabled"}) {
+		t.Errorf("Delete errors = %v, want Deletion Protection Enabled", got)
+	}
+
+	workspace, err := f.GetWorkspace(context.Background(), data.ID.ValueString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workspace.ArchivedAt != "" {
+		t.Error("protected workspace was archived")
+	}
+}
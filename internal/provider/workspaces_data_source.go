@@ -7,7 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/terraform-mars/terraform-provider-anthropic/internal/client"
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -19,12 +19,13 @@ func NewWorkspacesDataSource() datasource.DataSource {
 
 // WorkspacesDataSource defines the data source implementation.
 type WorkspacesDataSource struct {
-	client *client.Client
+	providerData *AnthropicProviderData
 }
 
 // WorkspacesDataSourceModel describes the data source data model.
 type WorkspacesDataSourceModel struct {
-	Workspaces []WorkspaceModel `tfsdk:"workspaces"`
+	Workspaces   []WorkspaceModel `tfsdk:"workspaces"`
+	Organization types.String     `tfsdk:"organization"`
 }
 
 // WorkspaceModel describes a single workspace in the list.
@@ -72,6 +73,10 @@ func (d *WorkspacesDataSource) Schema(ctx context.Context, req datasource.Schema
 					},
 				},
 			},
+			"organization": schema.StringAttribute{
+				Description: "The name of an entry in the provider's organizations map whose credentials are used for this data source. Defaults to the provider's admin_key.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -81,19 +86,22 @@ func (d *WorkspacesDataSource) Configure(ctx context.Context, req datasource.Con
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*AnthropicProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.AnthropicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = c
+	d.providerData = providerData
 }
 
 func (d *WorkspacesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "anthropic_workspaces", "read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data WorkspacesDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -102,12 +110,18 @@ func (d *WorkspacesDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
+	c, diags := d.providerData.clientFor(data.Organization)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Fetch all workspaces with pagination
-	var allWorkspaces []client.Workspace
+	var allWorkspaces []adminapi.Workspace
 	var afterID string
 
 	for {
-		workspaces, err := d.client.ListWorkspaces(ctx, 100, "", afterID)
+		workspaces, err := c.ListWorkspaces(ctx, 100, "", afterID)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list workspaces: %s", err))
 			return
@@ -0,0 +1,100 @@
+// Package tracing configures OpenTelemetry tracing for the provider process.
+//
+// Tracing is disabled unless one of the following environment variables is set:
+//
+//   - OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT exports
+//     spans over OTLP/HTTP. The other standard OTEL_EXPORTER_OTLP_* variables
+//     (headers, timeout, certificates) apply as usual.
+//   - ANTHROPIC_OTEL_TRACES_FILE appends spans as JSON to a local file.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Name is the instrumentation name of every tracer in the provider
+const Name = "github.com/terraform-mars/terraform-provider-anthropic"
+
+// Environment variables that enable tracing
+const (
+	EnvOTLPEndpoint       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	EnvOTLPTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	EnvTracesFile         = "ANTHROPIC_OTEL_TRACES_FILE"
+	EnvSDKDisabled        = "OTEL_SDK_DISABLED"
+)
+
+// Tracer returns the provider's tracer from the global tracer provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(Name)
+}
+
+// Setup installs a global tracer provider exporting to the destinations
+// configured through the environment. The returned function flushes and
+// stops the exporters and must be called before the process exits. When
+// tracing is not enabled, Setup leaves the no-op global tracer in place.
+func Setup(ctx context.Context, version string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	if disabled, _ := strconv.ParseBool(os.Getenv(EnvSDKDisabled)); disabled {
+		return noop, nil
+	}
+
+	var opts []sdktrace.TracerProviderOption
+
+	if os.Getenv(EnvOTLPEndpoint) != "" || os.Getenv(EnvOTLPTracesEndpoint) != "" {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return noop, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	var file *os.File
+	if path := os.Getenv(EnvTracesFile); path != "" {
+		var err error
+		file, err = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return noop, fmt.Errorf("failed to open traces file: %w", err)
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return noop, fmt.Errorf("failed to create file trace exporter: %w", err)
+		}
+		// Spans are written as they end so none are lost if Terraform kills the provider
+		opts = append(opts, sdktrace.WithSyncer(exporter))
+	}
+
+	if len(opts) == 0 {
+		return noop, nil
+	}
+
+	res := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName("terraform-provider-anthropic"),
+		semconv.ServiceVersion(version),
+	)
+	provider := sdktrace.NewTracerProvider(append(opts, sdktrace.WithResource(res))...)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if file != nil {
+			err = errors.Join(err, file.Close())
+		}
+		return err
+	}, nil
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/terraform-mars/terraform-provider-anthropic/internal/importgen"
 	"github.com/terraform-mars/terraform-provider-anthropic/internal/provider"
+	"github.com/terraform-mars/terraform-provider-anthropic/internal/tracing"
 )
 
 var (
@@ -14,6 +18,14 @@ var (
 )
 
 func main() {
+	// generate-imports writes import blocks for an existing organization
+	if len(os.Args) > 1 && os.Args[1] == "generate-imports" {
+		if err := importgen.Run(context.Background(), os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -24,7 +36,19 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	// Export spans when OpenTelemetry is configured through the environment
+	shutdownTracing, err := tracing.Setup(context.Background(), version)
+	if err != nil {
+		log.Printf("[WARN] tracing disabled: %s", err)
+	}
+
+	err = providerserver.Serve(context.Background(), provider.New(version), opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+		log.Printf("[WARN] failed to flush traces: %s", shutdownErr)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())
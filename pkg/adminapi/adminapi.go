@@ -0,0 +1,52 @@
+package adminapi
+
+import (
+	"context"
+)
+
+// Ensure Client implements AdminAPI.
+var _ AdminAPI = (*Client)(nil)
+
+// AdminAPI is the set of Admin API operations implemented by Client
+type AdminAPI interface {
+	// Organization
+	GetOrganization(ctx context.Context) (*Organization, error)
+
+	// Workspaces
+	ListWorkspaces(ctx context.Context, limit int, beforeID, afterID string) (*ListResponse[Workspace], error)
+	ListAllWorkspaces(ctx context.Context) ([]Workspace, error)
+	GetWorkspace(ctx context.Context, workspaceID string) (*Workspace, error)
+	CreateWorkspace(ctx context.Context, req *CreateWorkspaceRequest) (*Workspace, error)
+	UpdateWorkspace(ctx context.Context, workspaceID string, req *UpdateWorkspaceRequest) (*Workspace, error)
+	ArchiveWorkspace(ctx context.Context, workspaceID string) (*Workspace, error)
+
+	// API keys
+	ListAPIKeys(ctx context.Context, limit int, beforeID, afterID, status, workspaceID string) (*ListResponse[APIKey], error)
+	ListAllAPIKeys(ctx context.Context, status, workspaceID string) ([]APIKey, error)
+	GetAPIKey(ctx context.Context, apiKeyID string) (*APIKey, error)
+	CreateAPIKey(ctx context.Context, req *CreateAPIKeyRequest) (*APIKey, error)
+	UpdateAPIKey(ctx context.Context, apiKeyID string, req *UpdateAPIKeyRequest) (*APIKey, error)
+	DeleteAPIKey(ctx context.Context, apiKeyID string) error
+
+	// Workspace members
+	ListWorkspaceMembers(ctx context.Context, workspaceID string, limit int, beforeID, afterID string) (*ListResponse[WorkspaceMember], error)
+	ListAllWorkspaceMembers(ctx context.Context, workspaceID string) ([]WorkspaceMember, error)
+	GetWorkspaceMember(ctx context.Context, workspaceID, userID string) (*WorkspaceMember, error)
+	AddWorkspaceMember(ctx context.Context, workspaceID string, req *AddWorkspaceMemberRequest) (*WorkspaceMember, error)
+	UpdateWorkspaceMember(ctx context.Context, workspaceID, userID string, req *UpdateWorkspaceMemberRequest) (*WorkspaceMember, error)
+	RemoveWorkspaceMember(ctx context.Context, workspaceID, userID string) error
+
+	// Organization members
+	ListOrganizationMembers(ctx context.Context, limit int, beforeID, afterID string) (*ListResponse[OrganizationMember], error)
+	ListAllOrganizationMembers(ctx context.Context) ([]OrganizationMember, error)
+	GetOrganizationMember(ctx context.Context, userID string) (*OrganizationMember, error)
+	UpdateOrganizationMember(ctx context.Context, userID string, req *UpdateOrganizationMemberRequest) (*OrganizationMember, error)
+	RemoveOrganizationMember(ctx context.Context, userID string) error
+
+	// Invites
+	ListInvites(ctx context.Context, limit int, beforeID, afterID string) (*ListResponse[Invite], error)
+	ListAllInvites(ctx context.Context) ([]Invite, error)
+	GetInvite(ctx context.Context, inviteID string) (*Invite, error)
+	CreateInvite(ctx context.Context, req *CreateInviteRequest) (*Invite, error)
+	DeleteInvite(ctx context.Context, inviteID string) error
+}
@@ -0,0 +1,116 @@
+package adminapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditLogger appends one JSON line per mutating Admin API request
+type AuditLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// AuditEntry is a single line of the audit log
+type AuditEntry struct {
+	Timestamp    string          `json:"timestamp"`
+	Method       string          `json:"method"`
+	Path         string          `json:"path"`
+	ResourceType string          `json:"resource_type,omitempty"`
+	ResourceID   string          `json:"resource_id,omitempty"`
+	Status       int             `json:"status,omitempty"`
+	RequestID    string          `json:"request_id,omitempty"`
+	Body         json.RawMessage `json:"body,omitempty"`
+	Error        string          `json:"error,omitempty"`
+}
+
+// NewAuditLogger opens path for appending, creating it with 0600 permissions if needed
+func NewAuditLogger(path string) (*AuditLogger, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &AuditLogger{w: f}, nil
+}
+
+// Log appends entry to the audit log
+func (l *AuditLogger) Log(entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, err = l.w.Write(append(line, '\n'))
+	return err
+}
+
+// resourceContextKey is the context key of the resource performing a request
+type resourceContextKey struct{}
+
+// resourceInfo identifies the Terraform resource performing a request
+type resourceInfo struct {
+	Type string
+	ID   string
+}
+
+// ContextWithResource returns a context recording the Terraform resource type
+// and ID on whose behalf requests are made, for use in the audit log.
+func ContextWithResource(ctx context.Context, resourceType, resourceID string) context.Context {
+	return context.WithValue(ctx, resourceContextKey{}, resourceInfo{Type: resourceType, ID: resourceID})
+}
+
+// audit records a mutating request in the audit log, if one is configured.
+// Requests made before the resource has an ID, such as creates, are recorded
+// with the ID of the object in the response. A failure to write the entry
+// never fails the request itself since the change has already been made.
+func (c *Client) audit(ctx context.Context, method, path string, body []byte, resp *http.Response, respBody []byte, reqErr error) {
+	if c.AuditLog == nil || method == http.MethodGet {
+		return
+	}
+
+	entry := AuditEntry{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Method:    method,
+		Path:      path,
+		Body:      redactJSON(body),
+	}
+	if info, ok := ctx.Value(resourceContextKey{}).(resourceInfo); ok {
+		entry.ResourceType = info.Type
+		entry.ResourceID = info.ID
+	}
+	if resp != nil {
+		entry.Status = resp.StatusCode
+		entry.RequestID = resp.Header.Get("request-id")
+		if entry.ResourceID == "" && resp.StatusCode < 400 {
+			entry.ResourceID = responseObjectID(respBody)
+		}
+	}
+	if reqErr != nil {
+		entry.Error = reqErr.Error()
+	}
+
+	if err := c.AuditLog.Log(entry); err != nil {
+		log.Printf("[ERROR] failed to write audit log entry for %s %s: %s", method, path, err)
+	}
+}
+
+// responseObjectID returns the id field of a JSON response body, if any
+func responseObjectID(body []byte) string {
+	var object struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return ""
+	}
+	return object.ID
+}
@@ -0,0 +1,168 @@
+package adminapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// readAuditLog decodes every entry of the audit log at path.
+func readAuditLog(t *testing.T, path string) []AuditEntry {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("unable to decode audit entry %q: %s", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+func TestAuditLogger(t *testing.T) {
+	server, _ := newTestServer(t, http.StatusOK, `{"id":"wrkspc_1","name":"new"}`)
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	// Entries are appended to an existing log
+	if err := os.WriteFile(path, []byte(`{"method":"DELETE","path":"/v1/earlier"}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	auditLog, err := NewAuditLogger(path)
+	if err != nil {
+		t.Fatalf("NewAuditLogger: %s", err)
+	}
+	c := NewClient("sk-ant-admin-test", WithBaseURL(server.URL), WithAuditLogger(auditLog))
+
+	ctx := context.Background()
+	if _, err := c.GetWorkspace(ctx, "wrkspc_1"); err != nil {
+		t.Fatalf("GetWorkspace: %s", err)
+	}
+	if _, err := c.CreateWorkspace(ctx, &CreateWorkspaceRequest{Name: "new"}); err != nil {
+		t.Fatalf("CreateWorkspace: %s", err)
+	}
+
+	entries := readAuditLog(t, path)
+	if len(entries) != 2 {
+		t.Fatalf("audit log has %d entries, want the earlier entry and the create only: %+v", len(entries), entries)
+	}
+	entry := entries[1]
+	if entry.Method != http.MethodPost || entry.Path != "/v1/organizations/workspaces" || entry.Status != http.StatusOK || entry.ResourceID != "wrkspc_1" {
+		t.Errorf("unexpected audit entry %+v", entry)
+	}
+	if string(entry.Body) != `{"name":"new"}` {
+		t.Errorf("audit entry body = %s, want the request body", entry.Body)
+	}
+	if entry.Timestamp == "" {
+		t.Error("audit entry has no timestamp")
+	}
+}
+
+func TestAuditLoggerFilePermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not enforced on Windows")
+	}
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	if _, err := NewAuditLogger(path); err != nil {
+		t.Fatalf("NewAuditLogger: %s", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("audit log permissions = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestAuditLoggerTransportError(t *testing.T) {
+	server, _ := newTestServer(t, http.StatusOK, `{}`)
+	server.Close()
+
+	var log bytes.Buffer
+	c := NewClient("sk-ant-admin-test", WithBaseURL(server.URL), WithAuditLogger(&AuditLogger{w: &log}))
+
+	if _, err := c.ArchiveWorkspace(context.Background(), "wrkspc_1"); err == nil {
+		t.Fatal("ArchiveWorkspace succeeded against a closed server")
+	}
+
+	var entry AuditEntry
+	if err := json.Unmarshal(log.Bytes(), &entry); err != nil {
+		t.Fatalf("unable to decode audit entry %q: %s", log.String(), err)
+	}
+	if entry.Error == "" || entry.Status != 0 {
+		t.Errorf("audit entry %+v, want the transport error and no status", entry)
+	}
+}
+
+func TestClientAuditResourceID(t *testing.T) {
+	tests := []struct {
+		name       string
+		resourceID string
+		status     int
+		body       string
+		want       string
+	}{
+		{
+			name:   "created object",
+			status: http.StatusOK,
+			body:   `{"id":"wrkspc_new","name":"new"}`,
+			want:   "wrkspc_new",
+		},
+		{
+			name:       "existing resource",
+			resourceID: "wrkspc_1",
+			status:     http.StatusOK,
+			body:       `{"id":"wrkspc_other"}`,
+			want:       "wrkspc_1",
+		},
+		{
+			name:   "failed create",
+			status: http.StatusBadRequest,
+			body:   `{"type":"error","error":{"type":"invalid_request_error","message":"bad"}}`,
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newTestServer(t, tt.status, tt.body)
+			var log bytes.Buffer
+			c := NewClient("sk-ant-admin-test", WithBaseURL(server.URL), WithAuditLogger(&AuditLogger{w: &log}))
+
+			ctx := ContextWithResource(context.Background(), "anthropic_workspace", tt.resourceID)
+			c.CreateWorkspace(ctx, &CreateWorkspaceRequest{Name: "new"})
+
+			var entry AuditEntry
+			if err := json.Unmarshal(log.Bytes(), &entry); err != nil {
+				t.Fatalf("unable to decode audit entry %q: %s", log.String(), err)
+			}
+			if entry.ResourceID != tt.want {
+				t.Errorf("resource_id = %q, want %q", entry.ResourceID, tt.want)
+			}
+			if entry.ResourceType != "anthropic_workspace" || entry.Status != tt.status || entry.RequestID != "req_test" {
+				t.Errorf("unexpected audit entry %+v", entry)
+			}
+		})
+	}
+}
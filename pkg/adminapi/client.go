@@ -1,43 +1,73 @@
-package client
+package adminapi
 
 import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 const (
 	DefaultBaseURL    = "https://api.anthropic.com"
 	DefaultAPIVersion = "2023-06-01"
+	DefaultUserAgent  = "terraform-provider-anthropic"
 )
 
+// ErrReadOnly is returned for mutating requests made through a read-only client
+var ErrReadOnly = errors.New("client is read-only")
+
 // Client is the Anthropic Admin API client
 type Client struct {
 	BaseURL    string
 	AdminKey   string
 	APIVersion string
+	UserAgent  string
+	// Betas are sent as the anthropic-beta header to opt into beta features
+	Betas      []string
 	HTTPClient *http.Client
-}
-
-// NewClient creates a new Anthropic Admin API client
-func NewClient(adminKey string) *Client {
-	return &Client{
+	// ReadOnly rejects every non-GET request before it is sent
+	ReadOnly bool
+	// AuditLog records every mutating request when set
+	AuditLog *AuditLogger
+	// LogRedaction controls what is masked in debug logs, defaulting to
+	// RedactSecretsAndEmails
+	LogRedaction RedactionPolicy
+	// ExtraHeaders are added to every request. They cannot override the
+	// headers set by the client.
+	ExtraHeaders map[string]string
+	// Logger receives logs of every request, discarding them by default
+	Logger Logger
+	// Tracer starts a span for every request and paginated list, recording
+	// nothing by default
+	Tracer trace.Tracer
+}
+
+// NewClient creates a new Anthropic Admin API client authenticating with adminKey
+func NewClient(adminKey string, opts ...Option) *Client {
+	c := &Client{
 		BaseURL:    DefaultBaseURL,
 		AdminKey:   adminKey,
 		APIVersion: DefaultAPIVersion,
+		UserAgent:  DefaultUserAgent,
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		Logger: noopLogger{},
+		Tracer: noop.NewTracerProvider().Tracer(""),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
-}
-
-// WithBaseURL sets a custom base URL (useful for testing)
-func (c *Client) WithBaseURL(baseURL string) *Client {
-	c.BaseURL = baseURL
 	return c
 }
 
@@ -58,11 +88,100 @@ func (e *APIError) String() string {
 	return fmt.Sprintf("%s: %s", e.Type, e.Message)
 }
 
+// HTTPError is returned when the Anthropic API responds with an error status code
+type HTTPError struct {
+	StatusCode int
+	Body       string
+	// APIError is nil if the response body could not be decoded
+	APIError *APIError
+}
+
+func (e *HTTPError) Error() string {
+	if e.APIError == nil {
+		return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
+	}
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.APIError.String())
+}
+
+// IsNotFound reports whether err is an HTTPError with a 404 status code
+func IsNotFound(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
+}
+
+// IsAmbiguous reports whether a failed mutating request may nevertheless have
+// been applied by the server, such as after a timeout, a dropped connection or
+// a 5xx response. Requests interrupted by their caller, through cancellation
+// or the deadline of their context, are not ambiguous: the caller gave up on
+// them and must not retry them.
+func IsAmbiguous(err error) bool {
+	if err == nil || errors.Is(err, ErrReadOnly) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var interrupted *interruptedError
+	if errors.As(err, &interrupted) {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500 || httpErr.StatusCode == http.StatusRequestTimeout
+	}
+	return true
+}
+
+// interruptedError is returned when the context of a request ends before its
+// response arrives. It is distinct from the client's own timeout, which also
+// reports context.DeadlineExceeded.
+type interruptedError struct {
+	err error
+}
+
+func (e *interruptedError) Error() string {
+	return "request interrupted: " + e.err.Error()
+}
+
+func (e *interruptedError) Unwrap() error {
+	return e.err
+}
+
+// resendCountContextKey is the context key of the number of times a request
+// was sent before
+type resendCountContextKey struct{}
+
+// ContextWithResendCount returns a context marking its requests as retries of
+// a request already sent count times, which is recorded on their spans.
+func ContextWithResendCount(ctx context.Context, count int) context.Context {
+	return context.WithValue(ctx, resendCountContextKey{}, count)
+}
+
 // doRequest performs an HTTP request to the Anthropic Admin API
-func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) (err error) {
+	ctx, span := c.Tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.HTTPRequestMethodKey.String(method),
+		semconv.URLFull(c.BaseURL+path),
+	))
+	if count, _ := ctx.Value(resendCountContextKey{}).(int); count > 0 {
+		span.SetAttributes(semconv.HTTPRequestResendCount(count))
+	}
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if c.ReadOnly && method != http.MethodGet {
+		return fmt.Errorf("%w: refusing %s %s", ErrReadOnly, method, path)
+	}
+
 	var bodyReader io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
@@ -74,27 +193,79 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
+	for name, value := range c.ExtraHeaders {
+		req.Header.Set(name, value)
+	}
 	req.Header.Set("x-api-key", c.AdminKey)
-	req.Header.Set("anthropic-version", c.APIVersion)
+	apiVersion := c.apiVersion(ctx)
+	betaHeader := c.betaHeader(ctx)
+	req.Header.Set("anthropic-version", apiVersion)
+	if betaHeader != "" {
+		req.Header.Set("anthropic-beta", betaHeader)
+	}
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.UserAgent)
+	if idempotencyKey := idempotencyKey(ctx); idempotencyKey != "" && method != http.MethodGet {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
 
+	c.logTrace(ctx, "Sending Admin API request", map[string]interface{}{
+		"method":            method,
+		"url":               req.URL.String(),
+		"x-api-key":         c.AdminKey,
+		"anthropic-version": apiVersion,
+		"anthropic-beta":    betaHeader,
+		"body":              string(redactJSON(jsonBody)),
+	})
+
+	start := time.Now()
 	resp, err := c.HTTPClient.Do(req)
+	latency := time.Since(start)
 	if err != nil {
+		c.audit(ctx, method, path, jsonBody, nil, nil, err)
+		c.logDebug(ctx, "Admin API request failed", map[string]interface{}{
+			"method":     method,
+			"url":        req.URL.String(),
+			"latency_ms": latency.Milliseconds(),
+			"error":      err.Error(),
+		})
+		if ctx.Err() != nil {
+			return &interruptedError{err: err}
+		}
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
+	c.audit(ctx, method, path, jsonBody, resp, respBody, err)
 	if err != nil {
 		return fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	span.SetAttributes(
+		semconv.HTTPResponseStatusCode(resp.StatusCode),
+		attribute.String("anthropic.request_id", resp.Header.Get("request-id")),
+	)
+
+	c.logDebug(ctx, "Received Admin API response", map[string]interface{}{
+		"method":     method,
+		"url":        req.URL.String(),
+		"status":     resp.StatusCode,
+		"latency_ms": latency.Milliseconds(),
+		"request_id": resp.Header.Get("request-id"),
+	})
+	c.logTrace(ctx, "Admin API response body", map[string]interface{}{
+		"request_id": resp.Header.Get("request-id"),
+		"body":       string(redactJSON(respBody)),
+	})
+
 	if resp.StatusCode >= 400 {
+		httpErr := &HTTPError{StatusCode: resp.StatusCode, Body: string(respBody)}
 		var apiErr APIError
-		if err := json.Unmarshal(respBody, &apiErr); err != nil {
-			return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+		if err := json.Unmarshal(respBody, &apiErr); err == nil {
+			httpErr.APIError = &apiErr
 		}
-		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, apiErr.String())
+		return httpErr
 	}
 
 	if result != nil && len(respBody) > 0 {
@@ -503,3 +674,21 @@ func (c *Client) CreateInvite(ctx context.Context, req *CreateInviteRequest) (*I
 func (c *Client) DeleteInvite(ctx context.Context, inviteID string) error {
 	return c.doRequest(ctx, http.MethodDelete, "/v1/organizations/invites/"+inviteID, nil, nil)
 }
+
+// ============================================================================
+// Organization Operations
+// ============================================================================
+
+// Organization represents the organization an admin key belongs to
+type Organization struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// GetOrganization retrieves the organization the admin key belongs to
+func (c *Client) GetOrganization(ctx context.Context) (*Organization, error) {
+	var organization Organization
+	err := c.doRequest(ctx, http.MethodGet, "/v1/organizations/me", nil, &organization)
+	return &organization, err
+}
@@ -0,0 +1,250 @@
+package adminapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// newTestServer returns a server answering every request with body, and a
+// pointer to the headers of the last request it received.
+func newTestServer(t *testing.T, status int, body string) (*httptest.Server, *http.Header) {
+	t.Helper()
+
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		w.Header().Set("request-id", "req_test")
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &headers
+}
+
+func TestClientHeaders(t *testing.T) {
+	server, headers := newTestServer(t, http.StatusOK, `{"id":"org_1"}`)
+
+	c := NewClient("sk-ant-admin-test",
+		WithBaseURL(server.URL),
+		WithAPIVersion("2023-06-01"),
+		WithBetas("beta-a"),
+		WithUserAgent("test-agent/1.0"),
+		WithExtraHeaders(map[string]string{
+			"X-Team":            "platform",
+			"x-api-key":         "sk-ant-admin-override",
+			"anthropic-version": "1999-01-01",
+			"anthropic-beta":    "beta-override",
+			"Content-Type":      "text/plain",
+			"User-Agent":        "override/1.0",
+			"Idempotency-Key":   "override",
+		}),
+	)
+
+	ctx := ContextWithAPIVersion(context.Background(), "2024-01-01")
+	ctx = ContextWithBetas(ctx, "beta-b", "beta-a")
+	ctx = ContextWithIdempotencyKey(ctx, "idem-1")
+	if _, err := c.UpdateOrganizationMember(ctx, "user_1", &UpdateOrganizationMemberRequest{Role: "user"}); err != nil {
+		t.Fatalf("UpdateOrganizationMember: %s", err)
+	}
+
+	// Extra headers never override the headers set by the client, and the
+	// context overrides the client settings
+	want := map[string]string{
+		"X-Team":            "platform",
+		"X-Api-Key":         "sk-ant-admin-test",
+		"Anthropic-Version": "2024-01-01",
+		"Anthropic-Beta":    "beta-a,beta-b",
+		"Content-Type":      "application/json",
+		"User-Agent":        "test-agent/1.0",
+		"Idempotency-Key":   "idem-1",
+	}
+	for name, value := range want {
+		if got := headers.Get(name); got != value {
+			t.Errorf("header %s = %q, want %q", name, got, value)
+		}
+	}
+}
+
+func TestClientIdempotencyKeyOnlyOnMutatingRequests(t *testing.T) {
+	server, headers := newTestServer(t, http.StatusOK, `{"id":"org_1"}`)
+	c := NewClient("sk-ant-admin-test", WithBaseURL(server.URL))
+
+	ctx := ContextWithIdempotencyKey(context.Background(), "idem-1")
+	if _, err := c.GetOrganization(ctx); err != nil {
+		t.Fatalf("GetOrganization: %s", err)
+	}
+	if got := headers.Get("Idempotency-Key"); got != "" {
+		t.Errorf("GET sent Idempotency-Key %q, want none", got)
+	}
+}
+
+func TestIsAmbiguous(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "read-only", err: fmt.Errorf("%w: refusing POST", ErrReadOnly), want: false},
+		{name: "canceled", err: fmt.Errorf("request failed: %w", context.Canceled), want: false},
+		{name: "interrupted", err: &interruptedError{err: context.DeadlineExceeded}, want: false},
+		{name: "client timeout", err: fmt.Errorf("request failed: %w", context.DeadlineExceeded), want: true},
+		{name: "connection reset", err: errors.New("connection reset by peer"), want: true},
+		{name: "server error", err: &HTTPError{StatusCode: http.StatusBadGateway}, want: true},
+		{name: "request timeout", err: &HTTPError{StatusCode: http.StatusRequestTimeout}, want: true},
+		{name: "client error", err: &HTTPError{StatusCode: http.StatusConflict}, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsAmbiguous(tt.err); got != tt.want {
+			t.Errorf("IsAmbiguous(%s) = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClientTimeoutsAndInterruptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	// The client's own timeout may leave the request applied
+	c := NewClient("sk-ant-admin-test", WithBaseURL(server.URL), WithTimeout(50*time.Millisecond))
+	if _, err := c.CreateWorkspace(context.Background(), &CreateWorkspaceRequest{Name: "new"}); !IsAmbiguous(err) {
+		t.Errorf("CreateWorkspace after a client timeout = %v, want an ambiguous error", err)
+	}
+
+	// A request interrupted by its caller is not retried
+	c = NewClient("sk-ant-admin-test", WithBaseURL(server.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.CreateWorkspace(ctx, &CreateWorkspaceRequest{Name: "new"})
+	if err == nil || IsAmbiguous(err) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CreateWorkspace after its context ended = %v, want an unambiguous context error", err)
+	}
+}
+
+func TestClientReadOnly(t *testing.T) {
+	server, headers := newTestServer(t, http.StatusOK, `{"id":"wrkspc_1"}`)
+	c := NewClient("sk-ant-admin-test", WithBaseURL(server.URL), WithReadOnly(true))
+
+	_, err := c.CreateWorkspace(context.Background(), &CreateWorkspaceRequest{Name: "new"})
+	if !errors.Is(err, ErrReadOnly) {
+		t.Errorf("CreateWorkspace = %v, want ErrReadOnly", err)
+	}
+	if *headers != nil {
+		t.Error("read-only client sent a mutating request")
+	}
+	if IsAmbiguous(err) {
+		t.Error("a request refused by a read-only client is ambiguous")
+	}
+
+	if _, err := c.GetWorkspace(context.Background(), "wrkspc_1"); err != nil {
+		t.Errorf("GetWorkspace: %s", err)
+	}
+}
+
+// recordSpans returns an option recording the spans of a client, and the
+// recorder they are ended in.
+func recordSpans() (Option, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	return WithTracer(provider.Tracer("test")), recorder
+}
+
+// spanAttribute returns the value of the attribute key of span.
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestClientSpans(t *testing.T) {
+	tests := []struct {
+		name        string
+		readOnly    bool
+		status      int
+		resendCount int
+		wantErr     bool
+	}{
+		{name: "success", status: http.StatusOK},
+		{name: "retry", status: http.StatusOK, resendCount: 2},
+		{name: "error response", status: http.StatusInternalServerError, wantErr: true},
+		{name: "read-only", readOnly: true, status: http.StatusOK, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withTracer, recorder := recordSpans()
+			server, _ := newTestServer(t, tt.status, `{"id":"wrkspc_1"}`)
+			c := NewClient("sk-ant-admin-test", WithBaseURL(server.URL), WithReadOnly(tt.readOnly), withTracer)
+
+			ctx := ContextWithResendCount(context.Background(), tt.resendCount)
+			_, err := c.CreateWorkspace(ctx, &CreateWorkspaceRequest{Name: "new"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateWorkspace = %v, want error %t", err, tt.wantErr)
+			}
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("recorded %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+
+			resendCount, ok := spanAttribute(span, semconv.HTTPRequestResendCountKey)
+			if tt.resendCount == 0 && ok {
+				t.Errorf("first attempt has resend count %d", resendCount.AsInt64())
+			}
+			if tt.resendCount > 0 && resendCount.AsInt64() != int64(tt.resendCount) {
+				t.Errorf("resend count = %d, want %d", resendCount.AsInt64(), tt.resendCount)
+			}
+
+			if !tt.wantErr {
+				if span.Status().Code == codes.Error || len(span.Events()) != 0 {
+					t.Errorf("successful request has status %v and events %v", span.Status(), span.Events())
+				}
+				return
+			}
+			if span.Status().Code != codes.Error || span.Status().Description != err.Error() {
+				t.Errorf("span status = %+v, want the error %q", span.Status(), err)
+			}
+			if events := span.Events(); len(events) != 1 || events[0].Name != "exception" {
+				t.Errorf("span events = %v, want the recorded error", events)
+			}
+		})
+	}
+}
+
+func TestClientSpanMarshalError(t *testing.T) {
+	withTracer, recorder := recordSpans()
+	server, _ := newTestServer(t, http.StatusOK, `{}`)
+	c := NewClient("sk-ant-admin-test", WithBaseURL(server.URL), withTracer)
+
+	err := c.doRequest(context.Background(), http.MethodPost, "/v1/test", map[string]interface{}{"invalid": make(chan int)}, nil)
+	if err == nil {
+		t.Fatal("doRequest marshaled a channel")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Status().Code != codes.Error || len(spans[0].Events()) != 1 {
+		t.Errorf("marshal failure was not recorded on the request span: %v", spans)
+	}
+}
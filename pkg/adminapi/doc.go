@@ -0,0 +1,25 @@
+// Package adminapi is a Go client for the Anthropic Admin API, which manages
+// the workspaces, API keys, members and invites of an Anthropic organization.
+//
+// It is the client used by terraform-provider-anthropic and can be imported by
+// other tools, such as offboarding bots and key scanners. Create a Client
+// with an Admin API key (sk-ant-admin...) and any options, as shown in the
+// package example.
+//
+// Every method takes a context first. Methods named List* return a single
+// page, while ListAll* follow pagination and return every item.
+//
+// Errors returned by the API are *HTTPError values. Use IsNotFound to detect
+// missing objects.
+//
+// Code that only needs to call the API should accept the AdminAPI interface
+// rather than *Client, so that it can be exercised without HTTP.
+//
+// Logging and tracing are off by default. WithLogger and WithTracer send logs
+// and OpenTelemetry spans of every request to the given implementations.
+//
+// Per-request behavior is controlled through the context:
+// ContextWithAPIVersion and ContextWithBetas override the anthropic-version
+// and anthropic-beta headers, and ContextWithResource labels entries of the
+// audit log.
+package adminapi
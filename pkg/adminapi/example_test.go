@@ -0,0 +1,94 @@
+package adminapi_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+)
+
+// newExampleServer starts a stand-in for the Admin API serving a single
+// organization with two API keys, one of them created by user_departed.
+func newExampleServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/organizations/me":
+			io.WriteString(w, `{"id": "org_1", "type": "organization", "name": "Example Org"}`)
+		case r.URL.Path == "/v1/organizations/api_keys" && r.Method == http.MethodGet:
+			io.WriteString(w, `{"data": [
+				{"id": "apikey_1", "name": "ci", "status": "active", "created_by": {"id": "user_departed", "type": "user"}},
+				{"id": "apikey_2", "name": "prod", "status": "active", "created_by": {"id": "user_staying", "type": "user"}}
+			], "has_more": false}`)
+		case strings.HasPrefix(r.URL.Path, "/v1/organizations/api_keys/") && r.Method == http.MethodPost:
+			id := strings.TrimPrefix(r.URL.Path, "/v1/organizations/api_keys/")
+			fmt.Fprintf(w, `{"id": %q, "status": "inactive"}`, id)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"type": "error", "error": {"type": "not_found_error", "message": "Not found"}}`)
+		}
+	}))
+}
+
+func Example() {
+	server := newExampleServer()
+	defer server.Close()
+
+	c := adminapi.NewClient("sk-ant-admin-example",
+		adminapi.WithBaseURL(server.URL),
+		adminapi.WithUserAgent("offboarding-bot/1.0"),
+		adminapi.WithTimeout(10*time.Second),
+	)
+
+	organization, err := c.GetOrganization(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(organization.Name)
+	// Output: Example Org
+}
+
+func ExampleClient_ListAllAPIKeys() {
+	server := newExampleServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	c := adminapi.NewClient("sk-ant-admin-example", adminapi.WithBaseURL(server.URL))
+
+	// Deactivate the keys created by a user leaving the organization
+	keys, err := c.ListAllAPIKeys(ctx, "active", "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, key := range keys {
+		if key.CreatedBy == nil || key.CreatedBy.ID != "user_departed" {
+			continue
+		}
+		update := &adminapi.UpdateAPIKeyRequest{Status: "inactive"}
+		updated, err := c.UpdateAPIKey(ctx, key.ID, update)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(updated.ID, updated.Status)
+	}
+	// Output: apikey_1 inactive
+}
+
+func ExampleIsNotFound() {
+	server := newExampleServer()
+	defer server.Close()
+
+	c := adminapi.NewClient("sk-ant-admin-example", adminapi.WithBaseURL(server.URL))
+
+	_, err := c.GetWorkspace(context.Background(), "wrkspc_missing")
+	if adminapi.IsNotFound(err) {
+		fmt.Println("the workspace does not exist")
+	}
+	// Output: the workspace does not exist
+}
@@ -0,0 +1,548 @@
+// Package fake provides an in-memory implementation of adminapi.AdminAPI for
+// unit tests of code that manages an Anthropic organization.
+//
+// The fake keeps workspaces, API keys, members and invites in memory and
+// mimics the behavior of the Admin API that callers depend on: generated IDs,
+// pagination, status filters, archived workspaces archiving their keys, and
+// 404 errors that satisfy adminapi.IsNotFound.
+//
+//	f := fake.NewClient()
+//	f.AddOrganizationMember(adminapi.OrganizationMember{ID: "user_1", Email: "ada@example.com", Role: "developer"})
+//	f.Errors["CreateAPIKey"] = errors.New("connection reset")
+//
+//	err := rotateKeys(ctx, f) // rotateKeys accepts adminapi.AdminAPI
+//
+// The exported methods in fake_gen.go are generated from the AdminAPI
+// interface by go generate. Each one locks the fake, applies Errors and calls
+// the unexported method of the same name in this file, which implements the
+// behavior of the Admin API.
+package fake
+
+//go:generate go run ./internal/fakegen -o fake_gen.go
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+)
+
+// Ensure Client implements adminapi.AdminAPI.
+var _ adminapi.AdminAPI = (*Client)(nil)
+
+// Client is an in-memory Admin API. The zero value is not usable, use NewClient.
+type Client struct {
+	mu sync.Mutex
+
+	// Organization is returned by GetOrganization
+	Organization adminapi.Organization
+
+	// Errors makes the named method, such as "CreateAPIKey", fail with the
+	// given error without changing any state
+	Errors map[string]error
+
+	// Now returns the time recorded on created objects
+	Now func() time.Time
+
+	nextID int
+
+	workspaces map[string]*adminapi.Workspace
+	apiKeys    map[string]*adminapi.APIKey
+	members    map[string]*adminapi.WorkspaceMember
+	users      map[string]*adminapi.OrganizationMember
+	invites    map[string]*adminapi.Invite
+}
+
+// NewClient returns an empty in-memory Admin API
+func NewClient() *Client {
+	return &Client{
+		Organization: adminapi.Organization{ID: "org_fake", Type: "organization", Name: "Fake Organization"},
+		Errors:       map[string]error{},
+		Now:          time.Now,
+		workspaces:   map[string]*adminapi.Workspace{},
+		apiKeys:      map[string]*adminapi.APIKey{},
+		members:      map[string]*adminapi.WorkspaceMember{},
+		users:        map[string]*adminapi.OrganizationMember{},
+		invites:      map[string]*adminapi.Invite{},
+	}
+}
+
+// AddOrganizationMember adds a user to the organization. Users cannot be
+// created through the Admin API, so tests seed them with this method.
+func (f *Client) AddOrganizationMember(member adminapi.OrganizationMember) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if member.Type == "" {
+		member.Type = "user"
+	}
+	f.users[member.ID] = &member
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// fail returns the error configured for method, if any
+func (f *Client) fail(method string) error {
+	return f.Errors[method]
+}
+
+// newID returns a unique ID with prefix
+func (f *Client) newID(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s_fake%04d", prefix, f.nextID)
+}
+
+// timestamp returns the current time in the format used by the Admin API
+func (f *Client) timestamp() string {
+	return f.Now().UTC().Format(time.RFC3339)
+}
+
+// notFound returns the error the Admin API returns for a missing object
+func notFound(kind, id string) error {
+	apiErr := &adminapi.APIError{Type: "error"}
+	apiErr.Error.Type = "not_found_error"
+	apiErr.Error.Message = fmt.Sprintf("%s %s not found", kind, id)
+	return &adminapi.HTTPError{StatusCode: http.StatusNotFound, APIError: apiErr}
+}
+
+// invalid returns the error the Admin API returns for an invalid request
+func invalid(format string, args ...interface{}) error {
+	apiErr := &adminapi.APIError{Type: "error"}
+	apiErr.Error.Type = "invalid_request_error"
+	apiErr.Error.Message = fmt.Sprintf(format, args...)
+	return &adminapi.HTTPError{StatusCode: http.StatusBadRequest, APIError: apiErr}
+}
+
+// sortedValues returns copies of the values of items sorted by ID
+func sortedValues[T any](items map[string]*T, id func(T) string, keep func(T) bool) []T {
+	var values []T
+	for _, item := range items {
+		if keep == nil || keep(*item) {
+			values = append(values, *item)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return id(values[i]) < id(values[j]) })
+	return values
+}
+
+// page returns one page of items following the Admin API pagination rules
+func page[T any](items []T, id func(T) string, limit int, beforeID, afterID string) *adminapi.ListResponse[T] {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	start, end := 0, len(items)
+	for i, item := range items {
+		if afterID != "" && id(item) == afterID {
+			start = i + 1
+		}
+		if beforeID != "" && id(item) == beforeID {
+			end = i
+		}
+	}
+	if start > end {
+		start = end
+	}
+
+	resp := &adminapi.ListResponse[T]{Data: []T{}}
+	if beforeID != "" && end-start > limit {
+		start = end - limit
+		resp.HasMore = true
+	} else if end-start > limit {
+		end = start + limit
+		resp.HasMore = true
+	}
+	resp.Data = append(resp.Data, items[start:end]...)
+
+	if len(resp.Data) > 0 {
+		first, last := id(resp.Data[0]), id(resp.Data[len(resp.Data)-1])
+		resp.FirstID = &first
+		resp.LastID = &last
+	}
+	return resp
+}
+
+// ============================================================================
+// Organization
+// ============================================================================
+
+// getOrganization returns Organization
+func (f *Client) getOrganization(ctx context.Context) (*adminapi.Organization, error) {
+	organization := f.Organization
+	return &organization, nil
+}
+
+// ============================================================================
+// Workspaces
+// ============================================================================
+
+func workspaceID(w adminapi.Workspace) string { return w.ID }
+
+// listWorkspaces returns one page of workspaces
+func (f *Client) listWorkspaces(ctx context.Context, limit int, beforeID, afterID string) (*adminapi.ListResponse[adminapi.Workspace], error) {
+	return page(sortedValues(f.workspaces, workspaceID, nil), workspaceID, limit, beforeID, afterID), nil
+}
+
+// listAllWorkspaces returns every workspace
+func (f *Client) listAllWorkspaces(ctx context.Context) ([]adminapi.Workspace, error) {
+	return sortedValues(f.workspaces, workspaceID, nil), nil
+}
+
+// getWorkspace returns a workspace
+func (f *Client) getWorkspace(ctx context.Context, id string) (*adminapi.Workspace, error) {
+	workspace, ok := f.workspaces[id]
+	if !ok {
+		return nil, notFound("workspace", id)
+	}
+	result := *workspace
+	return &result, nil
+}
+
+// createWorkspace creates a workspace
+func (f *Client) createWorkspace(ctx context.Context, req *adminapi.CreateWorkspaceRequest) (*adminapi.Workspace, error) {
+	if req.Name == "" {
+		return nil, invalid("name is required")
+	}
+
+	workspace := &adminapi.Workspace{
+		ID:          f.newID("wrkspc"),
+		Type:        "workspace",
+		Name:        req.Name,
+		DisplayName: req.Name,
+		CreatedAt:   f.timestamp(),
+	}
+	f.workspaces[workspace.ID] = workspace
+	result := *workspace
+	return &result, nil
+}
+
+// updateWorkspace renames a workspace
+func (f *Client) updateWorkspace(ctx context.Context, id string, req *adminapi.UpdateWorkspaceRequest) (*adminapi.Workspace, error) {
+	workspace, ok := f.workspaces[id]
+	if !ok {
+		return nil, notFound("workspace", id)
+	}
+	if workspace.ArchivedAt != "" {
+		return nil, invalid("workspace %s is archived", id)
+	}
+
+	workspace.Name = req.Name
+	workspace.DisplayName = req.Name
+	result := *workspace
+	return &result, nil
+}
+
+// archiveWorkspace archives a workspace together with its API keys
+func (f *Client) archiveWorkspace(ctx context.Context, id string) (*adminapi.Workspace, error) {
+	workspace, ok := f.workspaces[id]
+	if !ok {
+		return nil, notFound("workspace", id)
+	}
+
+	if workspace.ArchivedAt == "" {
+		workspace.ArchivedAt = f.timestamp()
+		for _, apiKey := range f.apiKeys {
+			if apiKey.WorkspaceID == id {
+				apiKey.Status = "archived"
+			}
+		}
+	}
+	result := *workspace
+	return &result, nil
+}
+
+// ============================================================================
+// API Keys
+// ============================================================================
+
+func apiKeyID(k adminapi.APIKey) string { return k.ID }
+
+// apiKeyFilter keeps keys matching the optional status and workspace filters
+func apiKeyFilter(status, workspaceID string) func(adminapi.APIKey) bool {
+	return func(k adminapi.APIKey) bool {
+		return (status == "" || k.Status == status) && (workspaceID == "" || k.WorkspaceID == workspaceID)
+	}
+}
+
+// listAPIKeys returns one page of API keys matching the optional filters
+func (f *Client) listAPIKeys(ctx context.Context, limit int, beforeID, afterID, status, workspaceID string) (*adminapi.ListResponse[adminapi.APIKey], error) {
+	keys := sortedValues(f.apiKeys, apiKeyID, apiKeyFilter(status, workspaceID))
+	return page(keys, apiKeyID, limit, beforeID, afterID), nil
+}
+
+// listAllAPIKeys returns every API key matching the optional filters
+func (f *Client) listAllAPIKeys(ctx context.Context, status, workspaceID string) ([]adminapi.APIKey, error) {
+	return sortedValues(f.apiKeys, apiKeyID, apiKeyFilter(status, workspaceID)), nil
+}
+
+// getAPIKey returns an API key. The secret key is never returned.
+func (f *Client) getAPIKey(ctx context.Context, id string) (*adminapi.APIKey, error) {
+	apiKey, ok := f.apiKeys[id]
+	if !ok {
+		return nil, notFound("API key", id)
+	}
+	result := *apiKey
+	return &result, nil
+}
+
+// createAPIKey creates an active API key and returns it with its secret key
+func (f *Client) createAPIKey(ctx context.Context, req *adminapi.CreateAPIKeyRequest) (*adminapi.APIKey, error) {
+	if req.Name == "" {
+		return nil, invalid("name is required")
+	}
+	if req.WorkspaceID != "" {
+		workspace, ok := f.workspaces[req.WorkspaceID]
+		if !ok {
+			return nil, notFound("workspace", req.WorkspaceID)
+		}
+		if workspace.ArchivedAt != "" {
+			return nil, invalid("workspace %s is archived", req.WorkspaceID)
+		}
+	}
+
+	id := f.newID("apikey")
+	key := "sk-ant-api03-" + id
+	apiKey := &adminapi.APIKey{
+		ID:          id,
+		Type:        "api_key",
+		Name:        req.Name,
+		Hint:        key[len(key)-4:],
+		CreatedAt:   f.timestamp(),
+		CreatedBy:   &adminapi.Actor{ID: "user_fake_admin", Type: "user"},
+		Status:      "active",
+		WorkspaceID: req.WorkspaceID,
+	}
+	f.apiKeys[id] = apiKey
+
+	result := *apiKey
+	result.Key = key
+	return &result, nil
+}
+
+// updateAPIKey renames an API key or changes its status
+func (f *Client) updateAPIKey(ctx context.Context, id string, req *adminapi.UpdateAPIKeyRequest) (*adminapi.APIKey, error) {
+	apiKey, ok := f.apiKeys[id]
+	if !ok {
+		return nil, notFound("API key", id)
+	}
+	if apiKey.Status == "archived" {
+		return nil, invalid("API key %s is archived", id)
+	}
+
+	if req.Name != "" {
+		apiKey.Name = req.Name
+	}
+	if req.Status != "" {
+		if req.Status != "active" && req.Status != "inactive" {
+			return nil, invalid("invalid status %q", req.Status)
+		}
+		apiKey.Status = req.Status
+	}
+	result := *apiKey
+	return &result, nil
+}
+
+// deleteAPIKey deletes an API key
+func (f *Client) deleteAPIKey(ctx context.Context, id string) error {
+	if _, ok := f.apiKeys[id]; !ok {
+		return notFound("API key", id)
+	}
+	delete(f.apiKeys, id)
+	return nil
+}
+
+// ============================================================================
+// Workspace Members
+// ============================================================================
+
+func memberKey(workspaceID, userID string) string { return workspaceID + "/" + userID }
+
+func memberID(m adminapi.WorkspaceMember) string { return m.UserID }
+
+// listWorkspaceMembers returns one page of the members of a workspace
+func (f *Client) listWorkspaceMembers(ctx context.Context, workspaceID string, limit int, beforeID, afterID string) (*adminapi.ListResponse[adminapi.WorkspaceMember], error) {
+	if _, ok := f.workspaces[workspaceID]; !ok {
+		return nil, notFound("workspace", workspaceID)
+	}
+	members := sortedValues(f.members, memberID, func(m adminapi.WorkspaceMember) bool { return m.WorkspaceID == workspaceID })
+	return page(members, memberID, limit, beforeID, afterID), nil
+}
+
+// listAllWorkspaceMembers returns every member of a workspace
+func (f *Client) listAllWorkspaceMembers(ctx context.Context, workspaceID string) ([]adminapi.WorkspaceMember, error) {
+	if _, ok := f.workspaces[workspaceID]; !ok {
+		return nil, notFound("workspace", workspaceID)
+	}
+	return sortedValues(f.members, memberID, func(m adminapi.WorkspaceMember) bool { return m.WorkspaceID == workspaceID }), nil
+}
+
+// getWorkspaceMember returns a member of a workspace
+func (f *Client) getWorkspaceMember(ctx context.Context, workspaceID, userID string) (*adminapi.WorkspaceMember, error) {
+	member, ok := f.members[memberKey(workspaceID, userID)]
+	if !ok {
+		return nil, notFound("workspace member", memberKey(workspaceID, userID))
+	}
+	result := *member
+	return &result, nil
+}
+
+// addWorkspaceMember adds an organization member to a workspace
+func (f *Client) addWorkspaceMember(ctx context.Context, workspaceID string, req *adminapi.AddWorkspaceMemberRequest) (*adminapi.WorkspaceMember, error) {
+	if _, ok := f.workspaces[workspaceID]; !ok {
+		return nil, notFound("workspace", workspaceID)
+	}
+	if _, ok := f.users[req.UserID]; !ok {
+		return nil, notFound("user", req.UserID)
+	}
+	key := memberKey(workspaceID, req.UserID)
+	if _, ok := f.members[key]; ok {
+		return nil, invalid("user %s is already a member of workspace %s", req.UserID, workspaceID)
+	}
+
+	member := &adminapi.WorkspaceMember{
+		Type:          "workspace_member",
+		UserID:        req.UserID,
+		WorkspaceID:   workspaceID,
+		WorkspaceRole: req.WorkspaceRole,
+	}
+	f.members[key] = member
+	result := *member
+	return &result, nil
+}
+
+// updateWorkspaceMember changes the role of a workspace member
+func (f *Client) updateWorkspaceMember(ctx context.Context, workspaceID, userID string, req *adminapi.UpdateWorkspaceMemberRequest) (*adminapi.WorkspaceMember, error) {
+	member, ok := f.members[memberKey(workspaceID, userID)]
+	if !ok {
+		return nil, notFound("workspace member", memberKey(workspaceID, userID))
+	}
+
+	member.WorkspaceRole = req.WorkspaceRole
+	result := *member
+	return &result, nil
+}
+
+// removeWorkspaceMember removes a member from a workspace
+func (f *Client) removeWorkspaceMember(ctx context.Context, workspaceID, userID string) error {
+	key := memberKey(workspaceID, userID)
+	if _, ok := f.members[key]; !ok {
+		return notFound("workspace member", key)
+	}
+	delete(f.members, key)
+	return nil
+}
+
+// ============================================================================
+// Organization Members
+// ============================================================================
+
+func userID(m adminapi.OrganizationMember) string { return m.ID }
+
+// listOrganizationMembers returns one page of organization members
+func (f *Client) listOrganizationMembers(ctx context.Context, limit int, beforeID, afterID string) (*adminapi.ListResponse[adminapi.OrganizationMember], error) {
+	return page(sortedValues(f.users, userID, nil), userID, limit, beforeID, afterID), nil
+}
+
+// listAllOrganizationMembers returns every organization member
+func (f *Client) listAllOrganizationMembers(ctx context.Context) ([]adminapi.OrganizationMember, error) {
+	return sortedValues(f.users, userID, nil), nil
+}
+
+// getOrganizationMember returns an organization member
+func (f *Client) getOrganizationMember(ctx context.Context, id string) (*adminapi.OrganizationMember, error) {
+	user, ok := f.users[id]
+	if !ok {
+		return nil, notFound("user", id)
+	}
+	result := *user
+	return &result, nil
+}
+
+// updateOrganizationMember changes the role of an organization member
+func (f *Client) updateOrganizationMember(ctx context.Context, id string, req *adminapi.UpdateOrganizationMemberRequest) (*adminapi.OrganizationMember, error) {
+	user, ok := f.users[id]
+	if !ok {
+		return nil, notFound("user", id)
+	}
+
+	user.Role = req.Role
+	result := *user
+	return &result, nil
+}
+
+// removeOrganizationMember removes a user from the organization and all of its workspaces
+func (f *Client) removeOrganizationMember(ctx context.Context, id string) error {
+	if _, ok := f.users[id]; !ok {
+		return notFound("user", id)
+	}
+
+	delete(f.users, id)
+	for key, member := range f.members {
+		if member.UserID == id {
+			delete(f.members, key)
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// Invites
+// ============================================================================
+
+func inviteID(i adminapi.Invite) string { return i.ID }
+
+// listInvites returns one page of invites
+func (f *Client) listInvites(ctx context.Context, limit int, beforeID, afterID string) (*adminapi.ListResponse[adminapi.Invite], error) {
+	return page(sortedValues(f.invites, inviteID, nil), inviteID, limit, beforeID, afterID), nil
+}
+
+// listAllInvites returns every invite
+func (f *Client) listAllInvites(ctx context.Context) ([]adminapi.Invite, error) {
+	return sortedValues(f.invites, inviteID, nil), nil
+}
+
+// getInvite returns an invite
+func (f *Client) getInvite(ctx context.Context, id string) (*adminapi.Invite, error) {
+	invite, ok := f.invites[id]
+	if !ok {
+		return nil, notFound("invite", id)
+	}
+	result := *invite
+	return &result, nil
+}
+
+// createInvite creates a pending invite that expires in 21 days
+func (f *Client) createInvite(ctx context.Context, req *adminapi.CreateInviteRequest) (*adminapi.Invite, error) {
+	if req.Email == "" {
+		return nil, invalid("email is required")
+	}
+
+	now := f.Now().UTC()
+	invite := &adminapi.Invite{
+		ID:        f.newID("invite"),
+		Type:      "invite",
+		Email:     req.Email,
+		Role:      req.Role,
+		Status:    "pending",
+		CreatedAt: now.Format(time.RFC3339),
+		ExpiresAt: now.Add(21 * 24 * time.Hour).Format(time.RFC3339),
+	}
+	f.invites[invite.ID] = invite
+	result := *invite
+	return &result, nil
+}
+
+// deleteInvite deletes an invite
+func (f *Client) deleteInvite(ctx context.Context, id string) error {
+	if _, ok := f.invites[id]; !ok {
+		return notFound("invite", id)
+	}
+	delete(f.invites, id)
+	return nil
+}
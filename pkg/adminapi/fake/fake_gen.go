@@ -0,0 +1,328 @@
+// Code generated by fakegen from the adminapi.AdminAPI interface. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi"
+)
+
+// GetOrganization implements adminapi.AdminAPI with getOrganization, unless Errors["GetOrganization"] is set.
+func (f *Client) GetOrganization(ctx context.Context) (*adminapi.Organization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("GetOrganization"); err != nil {
+		return nil, err
+	}
+	return f.getOrganization(ctx)
+}
+
+// ListWorkspaces implements adminapi.AdminAPI with listWorkspaces, unless Errors["ListWorkspaces"] is set.
+func (f *Client) ListWorkspaces(ctx context.Context, limit int, beforeID, afterID string) (*adminapi.ListResponse[adminapi.Workspace], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("ListWorkspaces"); err != nil {
+		return nil, err
+	}
+	return f.listWorkspaces(ctx, limit, beforeID, afterID)
+}
+
+// ListAllWorkspaces implements adminapi.AdminAPI with listAllWorkspaces, unless Errors["ListAllWorkspaces"] is set.
+func (f *Client) ListAllWorkspaces(ctx context.Context) ([]adminapi.Workspace, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("ListAllWorkspaces"); err != nil {
+		return nil, err
+	}
+	return f.listAllWorkspaces(ctx)
+}
+
+// GetWorkspace implements adminapi.AdminAPI with getWorkspace, unless Errors["GetWorkspace"] is set.
+func (f *Client) GetWorkspace(ctx context.Context, workspaceID string) (*adminapi.Workspace, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("GetWorkspace"); err != nil {
+		return nil, err
+	}
+	return f.getWorkspace(ctx, workspaceID)
+}
+
+// CreateWorkspace implements adminapi.AdminAPI with createWorkspace, unless Errors["CreateWorkspace"] is set.
+func (f *Client) CreateWorkspace(ctx context.Context, req *adminapi.CreateWorkspaceRequest) (*adminapi.Workspace, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("CreateWorkspace"); err != nil {
+		return nil, err
+	}
+	return f.createWorkspace(ctx, req)
+}
+
+// UpdateWorkspace implements adminapi.AdminAPI with updateWorkspace, unless Errors["UpdateWorkspace"] is set.
+func (f *Client) UpdateWorkspace(ctx context.Context, workspaceID string, req *adminapi.UpdateWorkspaceRequest) (*adminapi.Workspace, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("UpdateWorkspace"); err != nil {
+		return nil, err
+	}
+	return f.updateWorkspace(ctx, workspaceID, req)
+}
+
+// ArchiveWorkspace implements adminapi.AdminAPI with archiveWorkspace, unless Errors["ArchiveWorkspace"] is set.
+func (f *Client) ArchiveWorkspace(ctx context.Context, workspaceID string) (*adminapi.Workspace, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("ArchiveWorkspace"); err != nil {
+		return nil, err
+	}
+	return f.archiveWorkspace(ctx, workspaceID)
+}
+
+// ListAPIKeys implements adminapi.AdminAPI with listAPIKeys, unless Errors["ListAPIKeys"] is set.
+func (f *Client) ListAPIKeys(ctx context.Context, limit int, beforeID, afterID, status, workspaceID string) (*adminapi.ListResponse[adminapi.APIKey], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("ListAPIKeys"); err != nil {
+		return nil, err
+	}
+	return f.listAPIKeys(ctx, limit, beforeID, afterID, status, workspaceID)
+}
+
+// ListAllAPIKeys implements adminapi.AdminAPI with listAllAPIKeys, unless Errors["ListAllAPIKeys"] is set.
+func (f *Client) ListAllAPIKeys(ctx context.Context, status, workspaceID string) ([]adminapi.APIKey, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("ListAllAPIKeys"); err != nil {
+		return nil, err
+	}
+	return f.listAllAPIKeys(ctx, status, workspaceID)
+}
+
+// GetAPIKey implements adminapi.AdminAPI with getAPIKey, unless Errors["GetAPIKey"] is set.
+func (f *Client) GetAPIKey(ctx context.Context, apiKeyID string) (*adminapi.APIKey, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("GetAPIKey"); err != nil {
+		return nil, err
+	}
+	return f.getAPIKey(ctx, apiKeyID)
+}
+
+// CreateAPIKey implements adminapi.AdminAPI with createAPIKey, unless Errors["CreateAPIKey"] is set.
+func (f *Client) CreateAPIKey(ctx context.Context, req *adminapi.CreateAPIKeyRequest) (*adminapi.APIKey, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("CreateAPIKey"); err != nil {
+		return nil, err
+	}
+	return f.createAPIKey(ctx, req)
+}
+
+// UpdateAPIKey implements adminapi.AdminAPI with updateAPIKey, unless Errors["UpdateAPIKey"] is set.
+func (f *Client) UpdateAPIKey(ctx context.Context, apiKeyID string, req *adminapi.UpdateAPIKeyRequest) (*adminapi.APIKey, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("UpdateAPIKey"); err != nil {
+		return nil, err
+	}
+	return f.updateAPIKey(ctx, apiKeyID, req)
+}
+
+// DeleteAPIKey implements adminapi.AdminAPI with deleteAPIKey, unless Errors["DeleteAPIKey"] is set.
+func (f *Client) DeleteAPIKey(ctx context.Context, apiKeyID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("DeleteAPIKey"); err != nil {
+		return err
+	}
+	return f.deleteAPIKey(ctx, apiKeyID)
+}
+
+// ListWorkspaceMembers implements adminapi.AdminAPI with listWorkspaceMembers, unless Errors["ListWorkspaceMembers"] is set.
+func (f *Client) ListWorkspaceMembers(ctx context.Context, workspaceID string, limit int, beforeID, afterID string) (*adminapi.ListResponse[adminapi.WorkspaceMember], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("ListWorkspaceMembers"); err != nil {
+		return nil, err
+	}
+	return f.listWorkspaceMembers(ctx, workspaceID, limit, beforeID, afterID)
+}
+
+// ListAllWorkspaceMembers implements adminapi.AdminAPI with listAllWorkspaceMembers, unless Errors["ListAllWorkspaceMembers"] is set.
+func (f *Client) ListAllWorkspaceMembers(ctx context.Context, workspaceID string) ([]adminapi.WorkspaceMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("ListAllWorkspaceMembers"); err != nil {
+		return nil, err
+	}
+	return f.listAllWorkspaceMembers(ctx, workspaceID)
+}
+
+// GetWorkspaceMember implements adminapi.AdminAPI with getWorkspaceMember, unless Errors["GetWorkspaceMember"] is set.
+func (f *Client) GetWorkspaceMember(ctx context.Context, workspaceID, userID string) (*adminapi.WorkspaceMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("GetWorkspaceMember"); err != nil {
+		return nil, err
+	}
+	return f.getWorkspaceMember(ctx, workspaceID, userID)
+}
+
+// AddWorkspaceMember implements adminapi.AdminAPI with addWorkspaceMember, unless Errors["AddWorkspaceMember"] is set.
+func (f *Client) AddWorkspaceMember(ctx context.Context, workspaceID string, req *adminapi.AddWorkspaceMemberRequest) (*adminapi.WorkspaceMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("AddWorkspaceMember"); err != nil {
+		return nil, err
+	}
+	return f.addWorkspaceMember(ctx, workspaceID, req)
+}
+
+// UpdateWorkspaceMember implements adminapi.AdminAPI with updateWorkspaceMember, unless Errors["UpdateWorkspaceMember"] is set.
+func (f *Client) UpdateWorkspaceMember(ctx context.Context, workspaceID, userID string, req *adminapi.UpdateWorkspaceMemberRequest) (*adminapi.WorkspaceMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("UpdateWorkspaceMember"); err != nil {
+		return nil, err
+	}
+	return f.updateWorkspaceMember(ctx, workspaceID, userID, req)
+}
+
+// RemoveWorkspaceMember implements adminapi.AdminAPI with removeWorkspaceMember, unless Errors["RemoveWorkspaceMember"] is set.
+func (f *Client) RemoveWorkspaceMember(ctx context.Context, workspaceID, userID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("RemoveWorkspaceMember"); err != nil {
+		return err
+	}
+	return f.removeWorkspaceMember(ctx, workspaceID, userID)
+}
+
+// ListOrganizationMembers implements adminapi.AdminAPI with listOrganizationMembers, unless Errors["ListOrganizationMembers"] is set.
+func (f *Client) ListOrganizationMembers(ctx context.Context, limit int, beforeID, afterID string) (*adminapi.ListResponse[adminapi.OrganizationMember], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("ListOrganizationMembers"); err != nil {
+		return nil, err
+	}
+	return f.listOrganizationMembers(ctx, limit, beforeID, afterID)
+}
+
+// ListAllOrganizationMembers implements adminapi.AdminAPI with listAllOrganizationMembers, unless Errors["ListAllOrganizationMembers"] is set.
+func (f *Client) ListAllOrganizationMembers(ctx context.Context) ([]adminapi.OrganizationMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("ListAllOrganizationMembers"); err != nil {
+		return nil, err
+	}
+	return f.listAllOrganizationMembers(ctx)
+}
+
+// GetOrganizationMember implements adminapi.AdminAPI with getOrganizationMember, unless Errors["GetOrganizationMember"] is set.
+func (f *Client) GetOrganizationMember(ctx context.Context, userID string) (*adminapi.OrganizationMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("GetOrganizationMember"); err != nil {
+		return nil, err
+	}
+	return f.getOrganizationMember(ctx, userID)
+}
+
+// UpdateOrganizationMember implements adminapi.AdminAPI with updateOrganizationMember, unless Errors["UpdateOrganizationMember"] is set.
+func (f *Client) UpdateOrganizationMember(ctx context.Context, userID string, req *adminapi.UpdateOrganizationMemberRequest) (*adminapi.OrganizationMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("UpdateOrganizationMember"); err != nil {
+		return nil, err
+	}
+	return f.updateOrganizationMember(ctx, userID, req)
+}
+
+// RemoveOrganizationMember implements adminapi.AdminAPI with removeOrganizationMember, unless Errors["RemoveOrganizationMember"] is set.
+func (f *Client) RemoveOrganizationMember(ctx context.Context, userID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("RemoveOrganizationMember"); err != nil {
+		return err
+	}
+	return f.removeOrganizationMember(ctx, userID)
+}
+
+// ListInvites implements adminapi.AdminAPI with listInvites, unless Errors["ListInvites"] is set.
+func (f *Client) ListInvites(ctx context.Context, limit int, beforeID, afterID string) (*adminapi.ListResponse[adminapi.Invite], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("ListInvites"); err != nil {
+		return nil, err
+	}
+	return f.listInvites(ctx, limit, beforeID, afterID)
+}
+
+// ListAllInvites implements adminapi.AdminAPI with listAllInvites, unless Errors["ListAllInvites"] is set.
+func (f *Client) ListAllInvites(ctx context.Context) ([]adminapi.Invite, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("ListAllInvites"); err != nil {
+		return nil, err
+	}
+	return f.listAllInvites(ctx)
+}
+
+// GetInvite implements adminapi.AdminAPI with getInvite, unless Errors["GetInvite"] is set.
+func (f *Client) GetInvite(ctx context.Context, inviteID string) (*adminapi.Invite, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("GetInvite"); err != nil {
+		return nil, err
+	}
+	return f.getInvite(ctx, inviteID)
+}
+
+// CreateInvite implements adminapi.AdminAPI with createInvite, unless Errors["CreateInvite"] is set.
+func (f *Client) CreateInvite(ctx context.Context, req *adminapi.CreateInviteRequest) (*adminapi.Invite, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("CreateInvite"); err != nil {
+		return nil, err
+	}
+	return f.createInvite(ctx, req)
+}
+
+// DeleteInvite implements adminapi.AdminAPI with deleteInvite, unless Errors["DeleteInvite"] is set.
+func (f *Client) DeleteInvite(ctx context.Context, inviteID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail("DeleteInvite"); err != nil {
+		return err
+	}
+	return f.deleteInvite(ctx, inviteID)
+}
@@ -0,0 +1,231 @@
+// Command fakegen generates the methods of the in-memory Admin API fake from
+// the adminapi.AdminAPI interface. Each generated method locks the fake,
+// fails with the error configured in Errors for its name, if any, and
+// otherwise calls the hand-written unexported method of the same name, so
+// that a method added to the interface fails to compile until the fake
+// implements it.
+//
+// It is run by go generate in pkg/adminapi/fake.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// interfaceName is the name of the interface the fake implements.
+const interfaceName = "AdminAPI"
+
+// packageName is the name of the package declaring interfaceName.
+const packageName = "adminapi"
+
+func main() {
+	src := flag.String("src", "..", "directory of the adminapi package")
+	out := flag.String("o", "fake_gen.go", "output file")
+	flag.Parse()
+
+	code, err := generate(*src)
+	if err != nil {
+		log.Fatalf("fakegen: %s", err)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		log.Fatalf("fakegen: %s", err)
+	}
+}
+
+// method is a single method of the interface.
+type method struct {
+	Name    string
+	Params  []param
+	Results []string
+}
+
+// param is a group of named parameters of the same type.
+type param struct {
+	Names []string
+	Type  string
+}
+
+// generate returns the formatted source of the fake methods for the
+// interface declared in the package in dir.
+func generate(dir string) ([]byte, error) {
+	methods, err := parseInterface(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by fakegen from the %s.%s interface. DO NOT EDIT.\n\n", packageName, interfaceName)
+	buf.WriteString("package fake\n\n")
+	buf.WriteString("import (\n\t\"context\"\n\n\t\"github.com/terraform-mars/terraform-provider-anthropic/pkg/adminapi\"\n)\n")
+
+	for _, m := range methods {
+		var params, args []string
+		for _, p := range m.Params {
+			params = append(params, strings.Join(p.Names, ", ")+" "+p.Type)
+			args = append(args, p.Names...)
+		}
+
+		var failure []string
+		for _, result := range m.Results {
+			if result == "error" {
+				failure = append(failure, "err")
+			} else {
+				failure = append(failure, "nil")
+			}
+		}
+
+		results := strings.Join(m.Results, ", ")
+		if len(m.Results) > 1 {
+			results = "(" + results + ")"
+		}
+
+		fmt.Fprintf(&buf, "\n// %s implements %s.%s with %s, unless Errors[%q] is set.\n", m.Name, packageName, interfaceName, unexported(m.Name), m.Name)
+		fmt.Fprintf(&buf, "func (f *Client) %s(%s) %s {\n", m.Name, strings.Join(params, ", "), results)
+		buf.WriteString("\tf.mu.Lock()\n\tdefer f.mu.Unlock()\n\n")
+		fmt.Fprintf(&buf, "\tif err := f.fail(%q); err != nil {\n\t\treturn %s\n\t}\n", m.Name, strings.Join(failure, ", "))
+		fmt.Fprintf(&buf, "\treturn f.%s(%s)\n}\n", unexported(m.Name), strings.Join(args, ", "))
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// parseInterface returns the methods of the interface in declaration order.
+func parseInterface(dir string) ([]method, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, ok := pkgs[packageName]
+	if !ok {
+		return nil, fmt.Errorf("package %s not found in %s", packageName, dir)
+	}
+
+	var iface *ast.InterfaceType
+	for _, file := range pkg.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok || spec.Name.Name != interfaceName {
+				return true
+			}
+			iface, _ = spec.Type.(*ast.InterfaceType)
+			return false
+		})
+	}
+	if iface == nil {
+		return nil, fmt.Errorf("interface %s not found in %s", interfaceName, dir)
+	}
+
+	var methods []method
+	for _, field := range iface.Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) != 1 {
+			return nil, fmt.Errorf("%s embeds another interface, which is not supported", interfaceName)
+		}
+
+		m := method{Name: field.Names[0].Name}
+		for i, p := range fn.Params.List {
+			typ, err := qualify(p.Type)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", m.Name, err)
+			}
+			group := param{Type: typ}
+			for _, name := range p.Names {
+				group.Names = append(group.Names, name.Name)
+			}
+			if len(group.Names) == 0 {
+				group.Names = []string{fmt.Sprintf("arg%d", i)}
+			}
+			m.Params = append(m.Params, group)
+		}
+		if fn.Results != nil {
+			for _, r := range fn.Results.List {
+				typ, err := qualify(r.Type)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", m.Name, err)
+				}
+				if typ != "error" && !nillable(r.Type) {
+					return nil, fmt.Errorf("%s: result type %s has no nil value", m.Name, typ)
+				}
+				for i := 0; i < max(len(r.Names), 1); i++ {
+					m.Results = append(m.Results, typ)
+				}
+			}
+		}
+		methods = append(methods, m)
+	}
+
+	return methods, nil
+}
+
+// qualify returns the source of a type expression of the adminapi package as
+// seen from another package.
+func qualify(expr ast.Expr) (string, error) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(e.Name) {
+			return packageName + "." + e.Name, nil
+		}
+		return e.Name, nil
+	case *ast.SelectorExpr:
+		pkg, ok := e.X.(*ast.Ident)
+		if !ok {
+			return "", fmt.Errorf("unsupported selector type %T", e.X)
+		}
+		return pkg.Name + "." + e.Sel.Name, nil
+	case *ast.StarExpr:
+		elem, err := qualify(e.X)
+		return "*" + elem, err
+	case *ast.ArrayType:
+		if e.Len != nil {
+			return "", fmt.Errorf("unsupported array type")
+		}
+		elem, err := qualify(e.Elt)
+		return "[]" + elem, err
+	case *ast.MapType:
+		key, err := qualify(e.Key)
+		if err != nil {
+			return "", err
+		}
+		value, err := qualify(e.Value)
+		return "map[" + key + "]" + value, err
+	case *ast.IndexExpr:
+		generic, err := qualify(e.X)
+		if err != nil {
+			return "", err
+		}
+		arg, err := qualify(e.Index)
+		return generic + "[" + arg + "]", err
+	default:
+		return "", fmt.Errorf("unsupported type %T", expr)
+	}
+}
+
+// nillable reports whether nil is a valid value of the type expression.
+func nillable(expr ast.Expr) bool {
+	switch expr.(type) {
+	case *ast.StarExpr, *ast.ArrayType, *ast.MapType, *ast.InterfaceType, *ast.FuncType, *ast.ChanType:
+		return true
+	}
+	return false
+}
+
+// unexported returns name with its first letter lowercased.
+func unexported(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestGeneratedFakeIsUpToDate(t *testing.T) {
+	want, err := generate("../../..")
+	if err != nil {
+		t.Fatalf("generate: %s", err)
+	}
+
+	got, err := os.ReadFile("../../fake_gen.go")
+	if err != nil {
+		t.Fatalf("unable to read the generated fake: %s", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Error("fake_gen.go is out of date with the AdminAPI interface, run go generate ./pkg/adminapi/fake")
+	}
+}
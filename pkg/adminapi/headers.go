@@ -0,0 +1,68 @@
+package adminapi
+
+import (
+	"context"
+	"strings"
+)
+
+// apiVersionContextKey is the context key of a per-call anthropic-version override
+type apiVersionContextKey struct{}
+
+// betasContextKey is the context key of per-call anthropic-beta features
+type betasContextKey struct{}
+
+// ContextWithAPIVersion returns a context whose requests send version as the
+// anthropic-version header instead of the client's APIVersion.
+func ContextWithAPIVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, apiVersionContextKey{}, version)
+}
+
+// ContextWithBetas returns a context whose requests opt into betas in
+// addition to the client's Betas and any betas already in ctx.
+func ContextWithBetas(ctx context.Context, betas ...string) context.Context {
+	existing, _ := ctx.Value(betasContextKey{}).([]string)
+	combined := append(append([]string{}, existing...), betas...)
+	return context.WithValue(ctx, betasContextKey{}, combined)
+}
+
+// idempotencyKeyContextKey is the context key of the idempotency key of a request
+type idempotencyKeyContextKey struct{}
+
+// ContextWithIdempotencyKey returns a context whose mutating requests send key
+// as the Idempotency-Key header, letting servers that support it recognize a
+// retried request instead of applying it twice.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// idempotencyKey returns the idempotency key of a request made with ctx
+func idempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// apiVersion returns the anthropic-version header for a request made with ctx
+func (c *Client) apiVersion(ctx context.Context) string {
+	if version, ok := ctx.Value(apiVersionContextKey{}).(string); ok && version != "" {
+		return version
+	}
+	return c.APIVersion
+}
+
+// betaHeader returns the anthropic-beta header for a request made with ctx,
+// or an empty string when no betas are enabled
+func (c *Client) betaHeader(ctx context.Context) string {
+	contextBetas, _ := ctx.Value(betasContextKey{}).([]string)
+
+	seen := map[string]bool{}
+	var betas []string
+	for _, beta := range append(append([]string{}, c.Betas...), contextBetas...) {
+		beta = strings.TrimSpace(beta)
+		if beta == "" || seen[beta] {
+			continue
+		}
+		seen[beta] = true
+		betas = append(betas, beta)
+	}
+	return strings.Join(betas, ",")
+}
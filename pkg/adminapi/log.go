@@ -0,0 +1,71 @@
+package adminapi
+
+import (
+	"context"
+	"regexp"
+)
+
+// Logger receives the client's logs of Admin API traffic. The client masks
+// secrets in the fields, and email addresses unless LogRedaction is
+// RedactSecrets, before passing them on.
+type Logger interface {
+	// Debug logs request outcomes such as response statuses and failures
+	Debug(ctx context.Context, msg string, fields map[string]interface{})
+	// Trace logs requests and the request and response bodies
+	Trace(ctx context.Context, msg string, fields map[string]interface{})
+}
+
+// noopLogger is the Logger of clients created without WithLogger
+type noopLogger struct{}
+
+func (noopLogger) Debug(context.Context, string, map[string]interface{}) {}
+
+func (noopLogger) Trace(context.Context, string, map[string]interface{}) {}
+
+// RedactionPolicy controls what is masked in client logs. Secrets are always masked.
+type RedactionPolicy string
+
+const (
+	// RedactSecrets masks API keys only
+	RedactSecrets RedactionPolicy = "secrets"
+	// RedactSecretsAndEmails masks API keys and email addresses
+	RedactSecretsAndEmails RedactionPolicy = "secrets_and_emails"
+)
+
+var (
+	// secretPattern matches Anthropic API and Admin API keys
+	secretPattern = regexp.MustCompile(`sk-ant-[A-Za-z0-9_-]+`)
+	// emailPattern matches email addresses
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// logDebug passes a debug log with its fields masked to the client's Logger
+func (c *Client) logDebug(ctx context.Context, msg string, fields map[string]interface{}) {
+	c.Logger.Debug(ctx, msg, c.maskLogFields(fields))
+}
+
+// logTrace passes a trace log with its fields masked to the client's Logger
+func (c *Client) logTrace(ctx context.Context, msg string, fields map[string]interface{}) {
+	c.Logger.Trace(ctx, msg, c.maskLogFields(fields))
+}
+
+// maskLogFields returns a copy of fields with the admin key, the secrets in
+// every string value and, depending on LogRedaction, email addresses masked
+func (c *Client) maskLogFields(fields map[string]interface{}) map[string]interface{} {
+	masked := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		if key == "x-api-key" {
+			masked[key] = redactedValue
+			continue
+		}
+		if s, ok := value.(string); ok {
+			s = secretPattern.ReplaceAllString(s, redactedValue)
+			if c.LogRedaction != RedactSecrets {
+				s = emailPattern.ReplaceAllString(s, redactedValue)
+			}
+			value = s
+		}
+		masked[key] = value
+	}
+	return masked
+}
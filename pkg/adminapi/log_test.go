@@ -0,0 +1,66 @@
+package adminapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// recordingLogger records the logs it receives.
+type recordingLogger struct {
+	logs []map[string]interface{}
+}
+
+func (l *recordingLogger) Debug(ctx context.Context, msg string, fields map[string]interface{}) {
+	l.logs = append(l.logs, fields)
+}
+
+func (l *recordingLogger) Trace(ctx context.Context, msg string, fields map[string]interface{}) {
+	l.logs = append(l.logs, fields)
+}
+
+func TestClientLogsAreMasked(t *testing.T) {
+	tests := []struct {
+		policy    RedactionPolicy
+		wantEmail bool
+	}{
+		{policy: RedactSecrets, wantEmail: true},
+		{policy: RedactSecretsAndEmails, wantEmail: false},
+		{policy: "", wantEmail: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			server, _ := newTestServer(t, http.StatusOK, `{"id":"invite_1","email":"ada@example.com","key":"sk-ant-api03-secret"}`)
+			logger := &recordingLogger{}
+			c := NewClient("sk-ant-admin-test", WithBaseURL(server.URL), WithLogger(logger), WithLogRedaction(tt.policy))
+
+			if _, err := c.CreateInvite(context.Background(), &CreateInviteRequest{Email: "ada@example.com", Role: "user"}); err != nil {
+				t.Fatalf("CreateInvite: %s", err)
+			}
+
+			var all strings.Builder
+			for _, fields := range logger.logs {
+				for key, value := range fields {
+					all.WriteString(key + "=")
+					if s, ok := value.(string); ok {
+						all.WriteString(s)
+					}
+					all.WriteString("\n")
+				}
+			}
+			logged := all.String()
+
+			if len(logger.logs) == 0 {
+				t.Fatal("client logged nothing")
+			}
+			if strings.Contains(logged, "sk-ant-") {
+				t.Errorf("logs contain a secret:\n%s", logged)
+			}
+			if strings.Contains(logged, "ada@example.com") != tt.wantEmail {
+				t.Errorf("logs contain the email = %t, want %t:\n%s", !tt.wantEmail, tt.wantEmail, logged)
+			}
+		})
+	}
+}
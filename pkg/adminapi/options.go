@@ -0,0 +1,142 @@
+package adminapi
+
+import (
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option configures a Client created by NewClient
+type Option func(*Client)
+
+// WithBaseURL sets the base URL of the Admin API. An empty URL keeps DefaultBaseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if baseURL != "" {
+			c.BaseURL = baseURL
+		}
+	}
+}
+
+// WithAPIVersion sets the anthropic-version header. An empty version keeps DefaultAPIVersion.
+func WithAPIVersion(version string) Option {
+	return func(c *Client) {
+		if version != "" {
+			c.APIVersion = version
+		}
+	}
+}
+
+// WithBetas opts every request into beta features through the anthropic-beta header
+func WithBetas(betas ...string) Option {
+	return func(c *Client) {
+		c.Betas = append(c.Betas, betas...)
+	}
+}
+
+// WithUserAgent sets the User-Agent header. An empty user agent keeps DefaultUserAgent.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		if userAgent != "" {
+			c.UserAgent = userAgent
+		}
+	}
+}
+
+// WithHTTPClient replaces the HTTP client used to send requests. A nil client
+// keeps the default client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.HTTPClient = httpClient
+		}
+	}
+}
+
+// WithTransport sets the transport of the client's HTTP client, such as one
+// built by NewTransport. A nil transport keeps http.DefaultTransport. The
+// client passed to WithHTTPClient is copied rather than modified.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		if transport != nil {
+			c.HTTPClient = copyHTTPClient(c.HTTPClient)
+			c.HTTPClient.Transport = transport
+		}
+	}
+}
+
+// WithTimeout sets the timeout of each request. The client passed to
+// WithHTTPClient is copied rather than modified.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.HTTPClient = copyHTTPClient(c.HTTPClient)
+		c.HTTPClient.Timeout = timeout
+	}
+}
+
+// copyHTTPClient returns a shallow copy of httpClient, which may be nil
+func copyHTTPClient(httpClient *http.Client) *http.Client {
+	if httpClient == nil {
+		return &http.Client{}
+	}
+	copied := *httpClient
+	return &copied
+}
+
+// WithExtraHeaders adds headers to every request. They cannot override the
+// headers set by the client.
+func WithExtraHeaders(headers map[string]string) Option {
+	return func(c *Client) {
+		if len(headers) == 0 {
+			return
+		}
+		if c.ExtraHeaders == nil {
+			c.ExtraHeaders = map[string]string{}
+		}
+		for name, value := range headers {
+			c.ExtraHeaders[name] = value
+		}
+	}
+}
+
+// WithReadOnly makes the client reject every non-GET request with ErrReadOnly
+func WithReadOnly(readOnly bool) Option {
+	return func(c *Client) {
+		c.ReadOnly = readOnly
+	}
+}
+
+// WithAuditLogger records every mutating request in auditLog
+func WithAuditLogger(auditLog *AuditLogger) Option {
+	return func(c *Client) {
+		c.AuditLog = auditLog
+	}
+}
+
+// WithLogRedaction sets what is masked in debug logs
+func WithLogRedaction(policy RedactionPolicy) Option {
+	return func(c *Client) {
+		c.LogRedaction = policy
+	}
+}
+
+// WithLogger sends logs of every request to logger. A nil logger keeps
+// discarding them.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		if logger != nil {
+			c.Logger = logger
+		}
+	}
+}
+
+// WithTracer starts a span with tracer for every request and paginated list.
+// A nil tracer keeps recording nothing.
+func WithTracer(tracer trace.Tracer) Option {
+	return func(c *Client) {
+		if tracer != nil {
+			c.Tracer = tracer
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package adminapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestOptionsDoNotModifyHTTPClient(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+	transport := &http.Transport{}
+
+	c := NewClient("sk-ant-admin-test", WithHTTPClient(httpClient), WithTransport(transport), WithTimeout(time.Second))
+
+	if httpClient.Timeout != time.Minute || httpClient.Transport != nil {
+		t.Errorf("options modified the caller's HTTP client: %+v", httpClient)
+	}
+	if c.HTTPClient.Timeout != time.Second || c.HTTPClient.Transport != transport {
+		t.Errorf("client HTTP client = %+v, want the transport and timeout applied", c.HTTPClient)
+	}
+}
+
+func TestOptionsWithNilValues(t *testing.T) {
+	c := NewClient("sk-ant-admin-test",
+		WithHTTPClient(nil),
+		WithTransport(nil),
+		WithTimeout(time.Second),
+		WithLogger(nil),
+		WithTracer(nil),
+	)
+
+	if c.HTTPClient == nil || c.HTTPClient.Timeout != time.Second {
+		t.Errorf("client HTTP client = %+v, want the default client with the timeout applied", c.HTTPClient)
+	}
+	if c.Logger == nil || c.Tracer == nil {
+		t.Error("nil logger or tracer replaced the no-op defaults")
+	}
+
+	// Clients built without an HTTP client still accept HTTP options
+	bare := &Client{}
+	WithTimeout(time.Second)(bare)
+	if bare.HTTPClient == nil || bare.HTTPClient.Timeout != time.Second {
+		t.Errorf("WithTimeout on a client without an HTTP client = %+v", bare.HTTPClient)
+	}
+}
@@ -0,0 +1,81 @@
+package adminapi
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// listPageSize is the page size used when walking through every page of a list
+const listPageSize = 100
+
+// listAll follows pagination until every item has been fetched
+func listAll[T any](ctx context.Context, c *Client, path string, fetch func(ctx context.Context, afterID string) (*ListResponse[T], error)) ([]T, error) {
+	ctx, span := c.Tracer.Start(ctx, "list "+path)
+	defer span.End()
+
+	var all []T
+	var afterID string
+
+	for pages := 1; ; pages++ {
+		page, err := fetch(ctx, afterID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "list failed")
+			return nil, err
+		}
+
+		all = append(all, page.Data...)
+
+		if !page.HasMore || page.LastID == nil {
+			span.SetAttributes(
+				attribute.Int("anthropic.list.pages", pages),
+				attribute.Int("anthropic.list.items", len(all)),
+			)
+			c.logDebug(ctx, "Listed all pages", map[string]interface{}{
+				"path":  path,
+				"pages": pages,
+				"items": len(all),
+			})
+			return all, nil
+		}
+		afterID = *page.LastID
+	}
+}
+
+// ListAllWorkspaces retrieves every workspace, following pagination
+func (c *Client) ListAllWorkspaces(ctx context.Context) ([]Workspace, error) {
+	return listAll(ctx, c, "/v1/organizations/workspaces", func(ctx context.Context, afterID string) (*ListResponse[Workspace], error) {
+		return c.ListWorkspaces(ctx, listPageSize, "", afterID)
+	})
+}
+
+// ListAllAPIKeys retrieves every API key matching the optional filters, following pagination
+func (c *Client) ListAllAPIKeys(ctx context.Context, status, workspaceID string) ([]APIKey, error) {
+	return listAll(ctx, c, "/v1/organizations/api_keys", func(ctx context.Context, afterID string) (*ListResponse[APIKey], error) {
+		return c.ListAPIKeys(ctx, listPageSize, "", afterID, status, workspaceID)
+	})
+}
+
+// ListAllWorkspaceMembers retrieves every member of a workspace, following pagination
+func (c *Client) ListAllWorkspaceMembers(ctx context.Context, workspaceID string) ([]WorkspaceMember, error) {
+	return listAll(ctx, c, fmt.Sprintf("/v1/organizations/workspaces/%s/members", workspaceID), func(ctx context.Context, afterID string) (*ListResponse[WorkspaceMember], error) {
+		return c.ListWorkspaceMembers(ctx, workspaceID, listPageSize, "", afterID)
+	})
+}
+
+// ListAllOrganizationMembers retrieves every organization member, following pagination
+func (c *Client) ListAllOrganizationMembers(ctx context.Context) ([]OrganizationMember, error) {
+	return listAll(ctx, c, "/v1/organizations/users", func(ctx context.Context, afterID string) (*ListResponse[OrganizationMember], error) {
+		return c.ListOrganizationMembers(ctx, listPageSize, "", afterID)
+	})
+}
+
+// ListAllInvites retrieves every invite, following pagination
+func (c *Client) ListAllInvites(ctx context.Context) ([]Invite, error) {
+	return listAll(ctx, c, "/v1/organizations/invites", func(ctx context.Context, afterID string) (*ListResponse[Invite], error) {
+		return c.ListInvites(ctx, listPageSize, "", afterID)
+	})
+}
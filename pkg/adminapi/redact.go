@@ -0,0 +1,56 @@
+package adminapi
+
+import (
+	"encoding/json"
+)
+
+// redactedValue replaces redacted values
+const redactedValue = "***"
+
+// secretFields are JSON fields whose values are always redacted
+var secretFields = map[string]bool{
+	"key":       true,
+	"admin_key": true,
+	"api_key":   true,
+}
+
+// redactJSON returns body with the values of secret fields masked. Bodies
+// that are not valid JSON are dropped entirely since they cannot be
+// inspected safely.
+func redactJSON(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return json.RawMessage(`"` + redactedValue + `"`)
+	}
+
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return json.RawMessage(`"` + redactedValue + `"`)
+	}
+	return redacted
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for field, fieldValue := range v {
+			if secretFields[field] {
+				v[field] = redactedValue
+				continue
+			}
+			v[field] = redactValue(fieldValue)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,51 @@
+package adminapi
+
+import "testing"
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "empty",
+			body: "",
+			want: "",
+		},
+		{
+			name: "no secrets",
+			body: `{"name":"ci","workspace_id":"wrkspc_1"}`,
+			want: `{"name":"ci","workspace_id":"wrkspc_1"}`,
+		},
+		{
+			name: "top-level secret",
+			body: `{"id":"apikey_1","key":"sk-ant-api03-secret"}`,
+			want: `{"id":"apikey_1","key":"***"}`,
+		},
+		{
+			name: "nested secrets",
+			body: `{"data":[{"api_key":"sk-ant-api03-a"},{"admin_key":"sk-ant-admin-b","name":"x"}]}`,
+			want: `{"data":[{"api_key":"***"},{"admin_key":"***","name":"x"}]}`,
+		},
+		{
+			name: "non-string secret",
+			body: `{"key":{"value":"sk-ant-api03-secret"}}`,
+			want: `{"key":"***"}`,
+		},
+		{
+			name: "invalid JSON",
+			body: `key=sk-ant-api03-secret`,
+			want: `"***"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redactJSON([]byte(tt.body))
+			if string(got) != tt.want {
+				t.Errorf("redactJSON(%s) = %s, want %s", tt.body, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package adminapi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// TransportOptions configures how a client connects to the Admin API
+type TransportOptions struct {
+	// ProxyURL overrides the proxy from the HTTPS_PROXY and NO_PROXY
+	// environment variables. Credentials in the URL are sent to the proxy.
+	ProxyURL string
+	// CACertPEM is trusted in addition to the system certificate pool
+	CACertPEM []byte
+	// ClientCertPEM and ClientKeyPEM are presented for mutual TLS
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	// InsecureSkipVerify disables server certificate verification
+	InsecureSkipVerify bool
+}
+
+// NewTransport builds an HTTP transport from opts, starting from the defaults
+// of http.DefaultTransport
+func NewTransport(opts TransportOptions) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, errors.New("invalid proxy URL: expected scheme://host[:port]")
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+
+	if len(opts.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(opts.CACertPEM) {
+			return nil, errors.New("no PEM certificates found in CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(opts.ClientCertPEM) > 0 || len(opts.ClientKeyPEM) > 0 {
+		cert, err := tls.X509KeyPair(opts.ClientCertPEM, opts.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}